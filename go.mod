@@ -7,20 +7,23 @@ require (
 	github.com/happy-sdk/happy v0.24.0
 	github.com/happy-sdk/happy/pkg/branding v0.1.0
 	github.com/happy-sdk/happy/pkg/cli/ansicolor v0.2.0
+	github.com/happy-sdk/happy/pkg/options v0.0.0-20240524194728-716f7cf590d5
 	github.com/happy-sdk/happy/pkg/strings/textfmt v0.3.1
 	github.com/happy-sdk/happy/pkg/vars v0.10.0
+	golang.org/x/crypto v0.24.0
+	golang.org/x/term v0.21.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	github.com/happy-sdk/happy/pkg/options v0.0.0-20240524194728-716f7cf590d5 // indirect
 	github.com/happy-sdk/happy/pkg/scheduling/cron v0.4.1 // indirect
 	github.com/happy-sdk/happy/pkg/settings v0.2.0 // indirect
 	github.com/happy-sdk/happy/pkg/strings/bexp v1.4.0 // indirect
 	github.com/happy-sdk/happy/pkg/strings/humanize v0.2.0 // indirect
 	github.com/happy-sdk/happy/pkg/version v0.1.2 // indirect
 	github.com/shopspring/decimal v1.3.1 // indirect
-	golang.org/x/mod v0.16.0 // indirect
-	golang.org/x/sys v0.17.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
+	golang.org/x/mod v0.17.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
 	golang.org/x/time v0.5.0 // indirect
 )
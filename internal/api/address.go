@@ -5,9 +5,10 @@
 package api
 
 import (
+	"context"
+
 	"github.com/cardano-community/koios-go-client/v4"
 	"github.com/happy-sdk/happy"
-	"github.com/happy-sdk/happy/pkg/vars/varflag"
 )
 
 const categoryAddress = "address"
@@ -16,26 +17,19 @@ const categoryAddress = "address"
 // https://api.koios.rest/#tag--Address
 func address(cmd *happy.Command, c *client) {
 	cmd.DescribeCategory(categoryAddress, "Query information about specific address(es)")
-	cmd.AddSubCommand(cmdAddressAddressInfo(c))
-	cmd.AddSubCommand(cmdAddressAddressUtxos(c))
-	cmd.AddSubCommand(cmdAddressCredentialUtxos(c))
-	cmd.AddSubCommand(cmdAddressAddressTxs(c))
-	cmd.AddSubCommand(cmdAddressCredentialTxs(c))
-	cmd.AddSubCommand(cmdAddressAddressAssets(c))
+	addSubCommand(cmd, cmdAddressAddressInfo(c))
+	addSubCommand(cmd, cmdAddressAddressUtxos(c))
+	addSubCommand(cmd, cmdAddressCredentialUtxos(c))
+	addSubCommand(cmd, cmdAddressAddressTxs(c))
+	addSubCommand(cmd, cmdAddressAddressOutputs(c))
+	addSubCommand(cmd, cmdAddressCredentialTxs(c))
+	addSubCommand(cmd, cmdAddressAddressAssets(c))
 }
 
 func cmdAddressAddressInfo(c *client) *happy.Command {
-	cmd := happy.NewCommand("address_info",
-		happy.Option("description", "Address Information"),
-		happy.Option("category", categoryAddress),
-		happy.Option("argn.max", 100),
-		happy.Option("usage", "koios api address_info [_addresses...] // max 100"),
-	).WithFlags(queryFlag)
-	cmd.AddInfo("Get address info - balance, associated stake address (if any) and UTxO set for given addresses")
+	cmd := endpointAddressInfo.command().WithFlags(queryFlag, fromFileFlag)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#post-/address_info
-
-  _addresses is constructed from command line arguments,
+  _addresses is constructed from command line arguments.
 
   Example: koios-cli api address_info \
     addr1qy2jt0qpqz2z2z9zx5w4xemekkce7yderz53kjue53lpqv90lkfa9sgrfjuz6uvt4uqtrqhl2kj0a9lnr9ndzutx32gqleeckv \
@@ -44,35 +38,18 @@ func cmdAddressAddressInfo(c *client) *happy.Command {
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		var addresses []koios.Address
-		for _, addr := range args.Args() {
-			addresses = append(addresses, koios.Address(addr.String()))
-		}
-		res, err := c.koios().GetAddressesInfo(sess, addresses, opts)
-		apiOutput(c.noFormat, res, err)
-		return nil
+		return c.batch(sess, args, endpointAddressInfo, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetAddressesInfo(ctx, argsOf[koios.Address](batch), opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdAddressAddressAssets(c *client) *happy.Command {
-	cmd := happy.NewCommand("address_assets",
-		happy.Option("description", "Address Assets"),
-		happy.Option("category", categoryAddress),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-		happy.Option("usage", "koios api address_assets [addresses...] // max 50"),
-	).WithFlags(queryFlag)
-	cmd.AddInfo("Get the list of all the assets (policy, name and quantity) for given addresses")
+	cmd := endpointAddressAssets.command().WithFlags(queryFlag, fromFileFlag)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#post-/address_assets
-
-  _addresses is constructed from command line arguments,
+  _addresses is constructed from command line arguments.
 
   Example: koios-cli api address_assets \
     addr1qy2jt0qpqz2z2z9zx5w4xemekkce7yderz53kjue53lpqv90lkfa9sgrfjuz6uvt4uqtrqhl2kj0a9lnr9ndzutx32gqleeckv \
@@ -81,37 +58,18 @@ func cmdAddressAddressAssets(c *client) *happy.Command {
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		var addresses []koios.Address
-		for _, addr := range args.Args() {
-			addresses = append(addresses, koios.Address(addr.String()))
-		}
-		res, err := c.koios().GetAddressesAssets(sess, addresses, opts)
-		apiOutput(c.noFormat, res, err)
-		return nil
+		return c.batch(sess, args, endpointAddressAssets, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetAddressesAssets(ctx, argsOf[koios.Address](batch), opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdAddressAddressTxs(c *client) *happy.Command {
-	cmd := happy.NewCommand("address_txs",
-		happy.Option("description", "Address Transactions"),
-		happy.Option("category", categoryAddress),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-		happy.Option("usage", "koios api address_txs --after-block-height 9945516 [addresses...] // max 50"),
-	).
-		WithFlags(varflag.UintFunc("after-block-height", 0, "Only fetch information after specific block height"))
-
-	cmd.AddInfo("Get the transaction hash list of input address array, optionally filtering after specified block height (inclusive)")
+	cmd := endpointAddressTxs.command().WithFlags(fromFileFlag)
 	cmd.AddInfo(`
-  Docs:https://api.koios.rest/#post-/address_txs
-
-  _addresses is constructed from command line arguments,
+  _addresses is constructed from command line arguments.
 
   Example: koios-cli api address_txs \
     --after-block-height 8000000 \
@@ -121,38 +79,36 @@ func cmdAddressAddressTxs(c *client) *happy.Command {
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		var addresses []koios.Address
-		for _, addr := range args.Args() {
-			addresses = append(addresses, koios.Address(addr.String()))
-		}
-
-		res, err := c.koios().GetAddressTxs(sess, addresses, args.Flag("after-block-height").Var().Uint64(), opts)
-		apiOutput(c.noFormat, res, err)
-		return nil
+		return c.batch(sess, args, endpointAddressTxs, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetAddressTxs(ctx, argsOf[koios.Address](batch), args.Flag("after-block-height").Var().Uint64(), opts)
+		})
 	})
 
 	return cmd
 }
 
-func cmdAddressAddressUtxos(c *client) *happy.Command {
-	cmd := happy.NewCommand("address_utxos",
-		happy.Option("description", "Address UTxOs"),
-		happy.Option("category", categoryAddress),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-		happy.Option("usage", "koios api address_utxos [addresses...] // max 50"),
-	).
-		WithFlags(varflag.BoolFunc("extended", false, "Controls whether or not certain optional fields supported by a given endpoint are populated as a part of the call", "e"))
-
-	cmd.AddInfo("Get the UTxO set for given addresses")
+func cmdAddressAddressOutputs(c *client) *happy.Command {
+	cmd := endpointAddressOutputs.command().WithFlags(fromFileFlag)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#post-/address_utxos
+  Example: koios-cli api address_outputs \
+    --after-block-height 8000000 \
+    addr1qy2jt0qpqz2z2z9zx5w4xemekkce7yderz53kjue53lpqv90lkfa9sgrfjuz6uvt4uqtrqhl2kj0a9lnr9ndzutx32gqleeckv
+
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.batch(sess, args, endpointAddressOutputs, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.post(ctx, "/address_outputs", endpointAddressOutputs.payload(args, "_addresses", batch), opts)
+		})
+	})
 
-  _addresses constructed from command line arguments,
+	return cmd
+}
+
+func cmdAddressAddressUtxos(c *client) *happy.Command {
+	cmd := endpointAddressUtxos.command().WithFlags(fromFileFlag)
+	cmd.AddInfo(`
+  _addresses is constructed from command line arguments.
 
   Example: koios-cli api address_utxos \
     --extended \
@@ -162,39 +118,18 @@ func cmdAddressAddressUtxos(c *client) *happy.Command {
     `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		var addresses []koios.Address
-		for _, addr := range args.Args() {
-			addresses = append(addresses, koios.Address(addr.String()))
-		}
-
-		res, err := c.koios().GetAddressUTxOs(sess, addresses, args.Flag("extended").Var().Bool(), opts)
-		apiOutput(c.noFormat, res, err)
-		return nil
+		return c.batch(sess, args, endpointAddressUtxos, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetAddressUTxOs(ctx, argsOf[koios.Address](batch), args.Flag("extended").Var().Bool(), opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdAddressCredentialTxs(c *client) *happy.Command {
-	cmd := happy.NewCommand("credential_txs",
-		happy.Option("description", "Transactions from payment credentials"),
-		happy.Option("category", categoryAddress),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-		happy.Option("usage", "koios api credential_txs --after-block-height 6238675 [credentials...] // max 50"),
-	).
-		WithFlags(varflag.UintFunc("after-block-height", 0, "Only fetch information after specific block height (inclusive)"))
-
-	cmd.AddInfo("Get the transaction hash list of input payment credential array, optionally filtering after specified block height (inclusive)")
-
+	cmd := endpointCredentialTxs.command().WithFlags(fromFileFlag)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#post-/credential_txs
-
-  _payment_credentials parameter is constructed from command line arguments,
+  _payment_credentials parameter is constructed from command line arguments.
 
   Example: koios-cli api credential_txs \
     --after-block-height 6238675 \
@@ -204,37 +139,17 @@ func cmdAddressCredentialTxs(c *client) *happy.Command {
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return nil
-		}
-		var credentials []koios.PaymentCredential
-		for _, cred := range args.Args() {
-			credentials = append(credentials, koios.PaymentCredential(cred.String()))
-		}
-		res, err := c.koios().GetCredentialTxs(sess, credentials, args.Flag("after-block-height").Var().Uint64(), opts)
-		apiOutput(c.noFormat, res, err)
-		return nil
+		return c.batch(sess, args, endpointCredentialTxs, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetCredentialTxs(ctx, argsOf[koios.PaymentCredential](batch), args.Flag("after-block-height").Var().Uint64(), opts)
+		})
 	})
 	return cmd
 }
 
 func cmdAddressCredentialUtxos(c *client) *happy.Command {
-	cmd := happy.NewCommand("credential_utxos",
-		happy.Option("description", "UTxOs from payment credentials"),
-		happy.Option("category", categoryAddress),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-		happy.Option("usage", "koios api credential_utxos [credentials...] // max 50"),
-	).
-		WithFlags(varflag.BoolFunc("extended", false, "Controls whether or not certain optional fields supported by a given endpoint are populated as a part of the call", "e"))
-
-	cmd.AddInfo("Get UTxO details for requested payment credentials")
-
+	cmd := endpointCredentialUtxos.command().WithFlags(fromFileFlag)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#post-/credential_utxos
-
-  _payment_credentials parameter is constructed from command line arguments,
+  _payment_credentials parameter is constructed from command line arguments.
 
   Example: koios-cli api credential_utxos \
     --extended \
@@ -244,17 +159,9 @@ func cmdAddressCredentialUtxos(c *client) *happy.Command {
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return nil
-		}
-		var credentials []koios.PaymentCredential
-		for _, cred := range args.Args() {
-			credentials = append(credentials, koios.PaymentCredential(cred.String()))
-		}
-		res, err := c.koios().GetCredentialUTxOs(sess, credentials, args.Flag("extended").Var().Bool(), opts)
-		apiOutput(c.noFormat, res, err)
-		return nil
+		return c.batch(sess, args, endpointCredentialUtxos, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetCredentialUTxOs(ctx, argsOf[koios.PaymentCredential](batch), args.Flag("extended").Var().Bool(), opts)
+		})
 	})
 
 	return cmd
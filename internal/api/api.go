@@ -6,14 +6,20 @@ package api
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/cardano-community/koios-cli/v2/internal/auth"
+	"github.com/cardano-community/koios-cli/v2/internal/config"
 	"github.com/cardano-community/koios-go-client/v4"
 	"github.com/happy-sdk/happy"
 	"github.com/happy-sdk/happy/pkg/vars/varflag"
@@ -21,25 +27,61 @@ import (
 
 const defaultOrigin = "https://github.com/cardano-community/koios-cli/v2"
 
+// deprecatedCommands maps former names of api commands to current names.
+// They are hidden aliases, see Aliases, kept for one release.
+var deprecatedCommands = map[string]string{}
+
+// deprecatedUsed is deprecated command name replaced by Aliases.
+var deprecatedUsed string
+
+// Aliases returns command line args with deprecated name of api command
+// replaced by its current name. It is called before args are parsed, so
+// deprecated names work without being listed in help.
+func Aliases(args []string) []string {
+	api := slices.Index(args, "api")
+	if api < 0 {
+		return args
+	}
+	for i := api + 1; i < len(args); i++ {
+		if name, ok := deprecatedCommands[args[i]]; ok {
+			deprecatedUsed = args[i]
+			args = slices.Clone(args)
+			args[i] = name
+			break
+		}
+	}
+	return args
+}
+
 var (
 	pagingFlags = []varflag.FlagCreateFunc{
 		varflag.UintFunc("page", 1, "Set page number for paginated response"),
-		varflag.UintFunc("page-size", koios.PageSize, "Set page size for paginated response"),
+		varflag.UintFunc("page-size", koios.PageSize, fmt.Sprintf("Set page size for paginated response, at most %d", koios.PageSize)),
+		varflag.BoolFunc("all", false, "Request all pages until last page, starting from --page"),
+		varflag.UintFunc("max-pages", 0, "Limit number of pages requested with --all, 0 for no limit"),
 	}
 
 	queryFlag = varflag.StringFunc("query", "", "Custom query for the request. e.g. key1=value1&key2=value2")
 
+	fromFileFlag = varflag.StringFunc("from-file", "", "Read arguments from file, separated by newline, comma or as json array")
+
 	// koios api params
 	epochNoFlag = varflag.UintFunc("epoch", 320, "Set epoch number")
 
-	afterBlockHeightFlag = varflag.UintFunc("after-block-height", 0, "Block height for specifying time delta")
+	extendedFlag = varflag.BoolFunc("extended", false, "Controls whether or not certain optional fields supported by a given endpoint are populated as a part of the call", "e")
 )
 
 type client struct {
 	mu           sync.Mutex
 	kc           *koios.Client
-	noFormat     bool
+	out          outputOptions
+	concurrency  uint
+	cache        *cacheTransport
 	subscription *auth.Subscription
+	quota        *quota
+	tokens       *tokenPool
+	failover     *failoverTransport
+	retry        *retryTransport
 }
 
 func Command() *happy.Command {
@@ -54,18 +96,43 @@ func Command() *happy.Command {
 		varflag.IntFunc("rate-limit", koios.DefaultRateLimit, "Set rate limit for the API server"),
 		varflag.StringFunc("origin", defaultOrigin, "Set origin for the API server"),
 		varflag.StringFunc("host", koios.MainnetHost, "Set host for the API server"),
-		varflag.BoolFunc("host-eu", false, "Use eu mainet network host"),
-		varflag.BoolFunc("host-preview", false, "Use preview network host"),
-		varflag.BoolFunc("host-preprod", false, "Use preprod network host"),
-		varflag.BoolFunc("host-guildnet", false, "Use guildnet network host"),
+		varflag.StringFunc("hosts", "", "Set comma separated list of fallback hosts tried in order when host fails"),
+		varflag.BoolFunc("probe-hosts", false, "Probe tip of each host and use fastest host not lagging behind"),
+		varflag.StringFunc("network", config.DefaultNetwork, "Use network preset, see koios-cli config networks"),
+		varflag.BoolFunc("host-eu", false, "Use eu mainet network host, alias of --network mainnet-eu"),
+		varflag.BoolFunc("host-preview", false, "Use preview network host, alias of --network preview"),
+		varflag.BoolFunc("host-preprod", false, "Use preprod network host, alias of --network preprod"),
+		varflag.BoolFunc("host-guildnet", false, "Use guildnet network host, alias of --network guildnet"),
 		varflag.BoolFunc("stats", false, "Enable request stats"),
 		varflag.BoolFunc("no-format", false, "prints response as machine readable json string"),
+		varflag.StringFunc("output", defaultOutputFormat, "Set output format: "+strings.Join(outputFormats(), "|"), "o"),
+		varflag.StringFunc("cache", cacheModeRead, "Set response cache mode: "+strings.Join(cacheModes, "|")),
+		varflag.BoolFunc("offline", false, "Answer requests only from response cache"),
 		varflag.DurationFunc("timeout", time.Duration(time.Minute), "Set timeout for the API server"),
+		varflag.UintFunc("retries", defaultRetries, "Set number of retries of idempotent requests failing with transient error"),
+		varflag.DurationFunc("retry-backoff", defaultRetryBackoff, "Set delay before first retry, doubled after each retry unless server sets Retry-After"),
+		varflag.UintFunc("concurrency", defaultConcurrency, "Set max number of concurrent requests when arguments are split into batches"),
+		varflag.UintFunc("quota-warn", defaultQuotaWarn, "Warn when requests today reach percent of daily request quota of the profile"),
+		varflag.UintFunc("quota-limit", defaultQuotaLimit, "Refuse requests exceeding percent of daily request quota of the profile"),
+		varflag.BoolFunc("force", false, "Send requests even when daily request quota of the profile would be exceeded"),
+		varflag.UintFunc("expiry-warn", auth.DefaultExpiryWarnDays, "Warn when token expires within number of days"),
+		varflag.StringFunc("profiles", "", "Rotate requests across comma separated list of profiles"),
 		varflag.StringFunc("auth", "", "JWT Bearer Auth token generated via https://koios.rest Profile page."),
 	)
 
 	api := &client{}
-	cmd.Before(api.configure)
+	cmd.Before(func(sess *happy.Session, args happy.Args) error {
+		if deprecatedUsed != "" {
+			sess.Log().Warn("command is deprecated and will be removed in next release",
+				slog.String("command", deprecatedUsed),
+				slog.String("use", deprecatedCommands[deprecatedUsed]),
+			)
+		}
+		// happy logs error and exits when before action fails
+		err := api.configure(sess, args)
+		setExitErr(err)
+		return err
+	})
 
 	// Add allcategorized subcommands
 	network(cmd, api)
@@ -76,6 +143,7 @@ func Command() *happy.Command {
 	address(cmd, api)
 	asset(cmd, api)
 	pool(cmd, api)
+	governance(cmd, api)
 	script(cmd, api)
 	ogmios(cmd, api)
 
@@ -85,67 +153,213 @@ func Command() *happy.Command {
 func (c *client) configure(sess *happy.Session, args happy.Args) (err error) {
 	sess.Log().Debug("configure koios api client")
 
-	apiVersion := args.Flag("api-version").String()
 	enableReqStats, err := args.Flag("stats").Var().Value().Bool()
 	if err != nil {
 		return err
 	}
 
-	c.noFormat = args.Flag("no-format").Var().Bool()
-	sheme := args.Flag("scheme").String()
-	host, err := getHost(args)
+	c.out.noFormat = args.Flag("no-format").Var().Bool()
+	c.out.format = args.Flag("output").String()
+	if _, ok := outputFormatters[c.out.format]; !ok {
+		return usageErrorf("unknown output format %q, supported formats are: %s", c.out.format, strings.Join(outputFormats(), ", "))
+	}
+	s, err := resolveSettings(sess, args)
 	if err != nil {
-		return err
+		return categorize(errCodeUsage, err)
+	}
+	c.concurrency = args.Flag("concurrency").Var().Uint()
+
+	cacheMode := args.Flag("cache").String()
+	if !slices.Contains(cacheModes, cacheMode) {
+		return usageErrorf("unknown cache mode %q, supported modes are: %s", cacheMode, strings.Join(cacheModes, ", "))
+	}
+	offline := args.Flag("offline").Var().Bool()
+	if offline && cacheMode != cacheModeRead {
+		return usageErrorf("offline mode can not be used with --cache=%s", cacheMode)
 	}
-	port := args.Flag("port").Var().Uint()
-	origin := defaultOrigin
-	if args.Flag("origin").Present() {
-		origin = args.Flag("origin").String()
+	c.cache = &cacheTransport{
+		dir:     filepath.Join(sess.Get("app.fs.path.cache").String(), "responses"),
+		mode:    cacheMode,
+		offline: offline,
+		log:     sess.Log(),
+	}
+
+	hosts := append([]string{s.host}, s.hosts...)
+	if args.Flag("probe-hosts").Var().Bool() && len(hosts) > 1 && !offline {
+		hosts = probeHosts(context.Background(), s, hosts, sess.Log())
+	}
+	c.failover = &failoverTransport{timeout: s.timeout, log: sess.Log()}
+	for _, host := range hosts {
+		c.failover.hosts = append(c.failover.hosts, hostAddr(host, s.port))
+	}
+	// timeout applies to each attempt, http client limits whole request
+	timeout := s.timeout * time.Duration(len(hosts))
+	if s.retries > 0 {
+		c.retry = &retryTransport{retries: s.retries, backoff: s.backoff, log: sess.Log()}
+		timeout = (timeout + max(maxRetryAfter, s.backoff<<s.retries)) * time.Duration(s.retries+1)
 	}
-	ratelimit := args.Flag("rate-limit").Var().Int()
-	duration := args.Flag("timeout").Var().Duration()
 
 	sess.Log().Debug(
 		"configutation",
-		slog.String("api-version", apiVersion),
+		slog.String("network", s.network),
+		slog.String("api-version", s.apiVersion),
 		slog.Bool("stats", enableReqStats),
-		slog.Bool("no-format", c.noFormat),
-		slog.Int("rate-limit", ratelimit),
-		slog.String("sheme", sheme),
-		slog.String("host", host),
-		slog.Uint64("port", uint64(port)),
-		slog.String("origin", origin),
-		slog.Duration("timeout", duration),
+		slog.Bool("no-format", c.out.noFormat),
+		slog.String("output", c.out.format),
+		slog.Int("rate-limit", s.rateLimit),
+		slog.Uint64("concurrency", uint64(c.concurrency)),
+		slog.String("sheme", s.scheme),
+		slog.String("host", hosts[0]),
+		slog.String("hosts", strings.Join(hosts[1:], ",")),
+		slog.Uint64("port", uint64(s.port)),
+		slog.String("origin", s.origin),
+		slog.Duration("timeout", s.timeout),
+		slog.Uint64("retries", uint64(s.retries)),
+		slog.Duration("retry-backoff", s.backoff),
+		slog.String("cache", cacheMode),
+		slog.Bool("offline", offline),
 	)
 	c.kc, err = koios.New(
-		koios.APIVersion(apiVersion),
+		koios.HTTPClient(&http.Client{Transport: c.transport()}),
+		koios.APIVersion(s.apiVersion),
 		koios.EnableRequestsStats(enableReqStats),
-		koios.Scheme(sheme),
-		koios.Host(host),
-		koios.Origin(origin),
-		koios.Port(uint16(port)),
-		koios.RateLimit(ratelimit),
-		koios.Timeout(duration),
+		koios.Scheme(s.scheme),
+		koios.Host(hosts[0]),
+		koios.Origin(s.origin),
+		koios.Port(s.port),
+		koios.RateLimit(s.rateLimit),
+		koios.Timeout(timeout),
 	)
+	if err != nil {
+		return err
+	}
+	return categorize(errCodeAuth, c.configureAuth(sess, args))
+}
 
+// configureAuth sets token used by the client. Token is taken from --auth
+// or --profile flag, then from auth.AuthEnv or auth.ProfileEnv environment
+// variable and last from default profile. Without token public tier is used.
+func (c *client) configureAuth(sess *happy.Session, args happy.Args) error {
 	if args.Flag("profile").Present() && args.Flag("auth").Present() {
-		return fmt.Errorf("profile and auth flags cannot be used together")
+		return usageErrorf("profile and auth flags cannot be used together")
+	}
+	if args.Flag("profiles").Present() {
+		if args.Flag("profile").Present() || args.Flag("auth").Present() {
+			return usageErrorf("profiles flag cannot be used together with profile or auth flags")
+		}
+		return c.configureTokenPool(sess, args)
 	}
 
-	if args.Flag("auth").Present() {
-		if err := c.kc.SetAuth(args.Flag("auth").String()); err != nil {
+	var (
+		token, profile, file, source string
+		err                          error
+	)
+	switch {
+	case args.Flag("auth").Present():
+		token, source = args.Flag("auth").String(), auth.SourceFlag
+	case args.Flag("profile").Present():
+		profile, source = sess.Get("app.profile.name").String(), auth.SourceFlag
+		file = filepath.Join(sess.Get("app.fs.path.config").String(), "koios.subscription")
+	case os.Getenv(auth.AuthEnv) != "":
+		if os.Getenv(auth.ProfileEnv) != "" {
+			return usageErrorf("%s and %s environment variables cannot be used together", auth.ProfileEnv, auth.AuthEnv)
+		}
+		token, source = os.Getenv(auth.AuthEnv), auth.SourceEnv
+	default:
+		if profile, file, source, err = auth.ActiveProfile(sess); err != nil {
+			return err
+		}
+	}
+
+	expiryWarn := args.Flag("expiry-warn").Var().Uint()
+	if token != "" {
+		sess.Log().Debug("using auth token", slog.String("source", source))
+		authInfo, err := koios.GetTokenAuthInfo(token)
+		if err != nil {
+			return fmt.Errorf("failed to read auth token: %w", err)
+		}
+		if err := auth.CheckExpiry(sess.Log(), authInfo, expiryWarn); err != nil {
+			return fmt.Errorf("auth token can not be used: %w", err)
+		}
+		if err := c.kc.SetAuth(token); err != nil {
 			return fmt.Errorf("failed to set auth token: %w", err)
 		}
-	} else if args.Flag("profile").Present() {
-		subscription, err := auth.LoadSubscription(sess)
+		return nil
+	}
+	if profile == "" {
+		sess.Log().Debug("no profile selected, using public tier")
+		return nil
+	}
+
+	sess.Log().Debug("using profile", slog.String("profile", profile), slog.String("source", source))
+	subscription, err := auth.LoadSubscriptionFile(file)
+	if err != nil {
+		return err
+	}
+	c.subscription = subscription
+	authInfo, err := koios.GetTokenAuthInfo(subscription.JWT)
+	if err != nil {
+		return err
+	}
+	if err := auth.CheckExpiry(sess.Log(), authInfo, expiryWarn); err != nil {
+		return fmt.Errorf("profile %s can not be used: %w, renew it with: koios-cli auth renew %s <jwt-token>", profile, err, profile)
+	}
+	c.quota, err = newQuota(
+		authInfo,
+		args.Flag("quota-warn").Var().Uint(),
+		args.Flag("quota-limit").Var().Uint(),
+		args.Flag("force").Var().Bool(),
+		sess.Log(),
+	)
+	if err != nil {
+		return err
+	}
+	return c.kc.SetAuth(subscription.JWT)
+}
+
+// configureTokenPool loads subscriptions of profiles listed
+// with --profiles flag and rotates requests across them.
+func (c *client) configureTokenPool(sess *happy.Session, args happy.Args) error {
+	names := profileNames(args.Flag("profiles").String())
+	if len(names) == 0 {
+		return usageErrorf("no profiles provided with profiles flag")
+	}
+	c.tokens = &tokenPool{
+		force: args.Flag("force").Var().Bool(),
+		log:   sess.Log(),
+	}
+	for _, name := range names {
+		profile, subscription, err := auth.LoadProfile(sess, name)
 		if err != nil {
 			return err
 		}
-		c.subscription = subscription
-		return c.kc.SetAuth(subscription.JWT)
+		authInfo, err := koios.GetTokenAuthInfo(subscription.JWT)
+		if err != nil {
+			return fmt.Errorf("profile %s: %w", profile, err)
+		}
+		if err := auth.CheckExpiry(sess.Log(), authInfo, args.Flag("expiry-warn").Var().Uint()); err != nil {
+			return fmt.Errorf("profile %s can not be used: %w, renew it with: koios-cli auth renew %s <jwt-token>", profile, err, profile)
+		}
+		q, err := newQuota(
+			authInfo,
+			args.Flag("quota-warn").Var().Uint(),
+			args.Flag("quota-limit").Var().Uint(),
+			args.Flag("force").Var().Bool(),
+			sess.Log(),
+		)
+		if err != nil {
+			return err
+		}
+		q.attrs = []slog.Attr{slog.String("profile", profile)}
+		c.tokens.members = append(c.tokens.members, &poolMember{
+			profile: profile,
+			sub:     subscription,
+			quota:   q,
+		})
 	}
-
-	return
+	sess.Log().Debug("using profiles", slog.String("profiles", strings.Join(names, ",")))
+	// token of each request is set by the token pool
+	return c.kc.SetAuth(c.tokens.members[0].sub.JWT)
 }
 
 func (c *client) koios() *koios.Client {
@@ -163,7 +377,12 @@ func (c *client) newRequestOpts(sess *happy.Session, args happy.Args) (*koios.Re
 		opts.SetCurrentPage(args.Flag("page").Var().Uint())
 	}
 	if args.Flag("page-size").Present() {
-		opts.SetPageSize(args.Flag("page-size").Var().Uint())
+		// koios returns at most koios.PageSize records per page
+		size := args.Flag("page-size").Var().Uint()
+		if size == 0 || size > koios.PageSize {
+			return nil, usageErrorf("--page-size must be between 1 and %d", koios.PageSize)
+		}
+		opts.SetPageSize(size)
 	}
 	if args.Flag("query").Present() {
 		qraw := args.Flag("query").String()
@@ -174,94 +393,68 @@ func (c *client) newRequestOpts(sess *happy.Session, args happy.Args) (*koios.Re
 		opts.QueryApply(q)
 	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.subscription != nil {
-		c.subscription.RequestsToday++
-		opts.SetRequestsToday(c.subscription.RequestsToday)
-		if err := c.subscription.Save(); err != nil {
-			return nil, fmt.Errorf("failed to save subscription: %w", err)
-		}
+		opts.SetRequestsToday(c.subscription.RequestsToday + 1)
 	}
 
 	// opts.SetPageSize(args.Flag("page-size").Var().Uint())
 	return opts, nil
 }
 
-func getHost(args happy.Args) (string, error) {
-	host := args.Flag("host").String()
-
-	hostflags := 0
-	if args.Flag("host").Present() {
-		host = args.Flag("host").String()
-		hostflags++
-	}
-	if args.Flag("host-eu").Present() && args.Flag("host-eu").Var().Bool() {
-		host = koios.MainnetHostEU
-		hostflags++
-	}
-	if args.Flag("host-preview").Present() && args.Flag("host-preview").Var().Bool() {
-		host = koios.PreviewHost
-		hostflags++
-	}
-	if args.Flag("host-preprod").Present() && args.Flag("host-preprod").Var().Bool() {
-		host = koios.PreProdHost
-		hostflags++
+// countRequest counts request sent to the koios api against the subscription.
+func (c *client) countRequest() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.subscription == nil {
+		return nil
 	}
-	if args.Flag("host-guildnet").Present() && args.Flag("host-guildnet").Var().Bool() {
-		host = koios.GuildHost
-		hostflags++
+	if err := c.quota.check(c.subscription.RequestsToday, 1); err != nil {
+		return err
 	}
-	if hostflags > 1 {
-		return "", fmt.Errorf("only one host flag can be used")
+	c.subscription.RequestsToday++
+	if err := c.subscription.Save(); err != nil {
+		return fmt.Errorf("failed to save subscription: %w", err)
 	}
-	return host, nil
+	return nil
 }
 
 // output koios api client responses.
-func apiOutput(noFormat bool, data any, err error) {
+func apiOutput(out outputOptions, data any, err error) {
 	if err != nil {
-		handleErr(noFormat, err)
+		handleErr(out, err)
 		return
 	}
 
-	buffer := &bytes.Buffer{}
-	encoder := json.NewEncoder(buffer)
-	encoder.SetEscapeHTML(false)
-
-	if !noFormat {
-		encoder.SetIndent("", "  ")
+	format, ok := outputFormatters[out.format]
+	if !ok {
+		format = outputFormatters[defaultOutputFormat]
 	}
 
-	if err := encoder.Encode(data); err != nil {
-		handleErr(noFormat, err)
+	buffer := &bytes.Buffer{}
+	if err := format(buffer, out, data); err != nil {
+		handleErr(out, err)
 		return
 	}
-	fmt.Println(buffer.String())
+	fmt.Print(buffer.String())
 }
 
-type apiError struct {
-	Error string `json:"error"`
-}
-
-func handleErr(noFormat bool, err error) bool {
+func handleErr(out outputOptions, err error) bool {
 	if err == nil {
 		return false
 	}
-	apiOutput(noFormat, apiError{err.Error()}, nil)
+	apiOutput(out, newAPIError(err), nil)
 	return true
 }
 
-func flagSlice(flags ...varflag.FlagCreateFunc) []varflag.FlagCreateFunc {
-	return flags
+// output writes response of single request and returns the request error.
+func (c *client) output(res any, err error) error {
+	err = requestErr(res, err)
+	apiOutput(c.out, res, err)
+	return err
 }
 
-func notimplCmd(category, name string) *happy.Command {
-	cmd := happy.NewCommand(name,
-		happy.Option("description", "NOT IMPLEMENTED"),
-		happy.Option("category", category),
-	)
-	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		sess.Log().NotImplemented(fmt.Sprintf("command %q not implemented", name))
-		return nil
-	})
-	return cmd
+func flagSlice(flags ...varflag.FlagCreateFunc) []varflag.FlagCreateFunc {
+	return flags
 }
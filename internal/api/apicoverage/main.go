@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+// Command apicoverage compares api commands registered in internal/api
+// against endpoints of the vendored Koios OpenAPI specification. It reads
+// only local files, so it runs offline.
+//
+//	go run ./internal/api/apicoverage [-v] [-spec file] [-dir dir]
+//
+// Spec and commands are looked up in internal/api package, so it runs from
+// any directory of the module. It exits with non-zero status when endpoint
+// has no command or command has no endpoint. Commands named as endpoint with dashes instead of
+// underscores are reported as renamed, but do not fail the check.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+const apiPackage = "github.com/cardano-community/koios-cli/v2/internal/api"
+
+const (
+	statusCovered = "covered"
+	statusRenamed = "renamed"
+	statusMissing = "missing"
+	statusUnknown = "unknown"
+)
+
+type endpoint struct {
+	path   string
+	method string
+}
+
+type result struct {
+	endpoint endpoint
+	command  string
+	status   string
+}
+
+func main() {
+	specFile := flag.String("spec", "", "Koios OpenAPI specification (default koiosapi.yaml of api package)")
+	dir := flag.String("dir", "", "Directory of api commands (default directory of api package)")
+	verbose := flag.Bool("v", false, "List covered endpoints too")
+	flag.Parse()
+
+	if *dir == "" || *specFile == "" {
+		pkg, err := build.Import(apiPackage, ".", build.FindOnly)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		if *dir == "" {
+			*dir = pkg.Dir
+		}
+		if *specFile == "" {
+			*specFile = filepath.Join(pkg.Dir, "koiosapi.yaml")
+		}
+	}
+
+	endpoints, err := specEndpoints(*specFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	commands, err := registeredCommands(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	results := coverage(endpoints, commands)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tMETHOD\tENDPOINT\tCOMMAND")
+	var covered, failed int
+	for _, r := range results {
+		switch r.status {
+		case statusCovered, statusRenamed:
+			covered++
+		default:
+			failed++
+		}
+		if r.status == statusCovered && !*verbose {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.status, r.endpoint.method, r.endpoint.path, r.command)
+	}
+	w.Flush()
+	fmt.Printf("\n%d of %d endpoints have api command\n", covered, len(endpoints))
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// coverage matches endpoints with commands named as the endpoint path.
+func coverage(endpoints []endpoint, commands []string) []result {
+	registered := make(map[string]bool, len(commands))
+	for _, name := range commands {
+		registered[name] = true
+	}
+	matched := make(map[string]bool)
+
+	var results []result
+	for _, e := range endpoints {
+		name := strings.TrimPrefix(e.path, "/")
+		r := result{endpoint: e, status: statusMissing}
+		switch dashed := strings.ReplaceAll(name, "_", "-"); {
+		case registered[name]:
+			r.command, r.status = name, statusCovered
+		case registered[dashed]:
+			r.command, r.status = dashed, statusRenamed
+		}
+		matched[r.command] = true
+		results = append(results, r)
+	}
+	for _, name := range commands {
+		if !matched[name] {
+			results = append(results, result{command: name, status: statusUnknown})
+		}
+	}
+	return results
+}
+
+// specEndpoints returns endpoints of OpenAPI specification sorted by path.
+func specEndpoints(file string) ([]endpoint, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var spec struct {
+		Paths map[string]map[string]yaml.Node `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	var endpoints []endpoint
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			endpoints = append(endpoints, endpoint{path: path, method: strings.ToUpper(method)})
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].path != endpoints[j].path {
+			return endpoints[i].path < endpoints[j].path
+		}
+		return endpoints[i].method < endpoints[j].method
+	})
+	return endpoints, nil
+}
+
+// registeredCommands returns names of commands added with addSubCommand.
+// Name of the command is first argument of happy.NewCommand called in
+// the function which constructs the command, or name of the endpoint
+// definition which command method is called.
+func registeredCommands(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		fset        = token.NewFileSet()
+		names       = make(map[string]string)
+		endpoints   = make(map[string]string)
+		commandVars = make(map[string]string)
+		constructed []string
+		funcs       []*ast.FuncDecl
+	)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Body != nil {
+					funcs = append(funcs, decl)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if vs, ok := spec.(*ast.ValueSpec); ok {
+						for i, value := range vs.Values {
+							if name, ok := endpointName(value); ok {
+								endpoints[vs.Names[i].Name] = name
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, fn := range funcs {
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if name, ok := newCommandName(call); ok {
+				if _, exists := names[fn.Name.Name]; !exists {
+					names[fn.Name.Name] = name
+				}
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "command" {
+				if id, ok := sel.X.(*ast.Ident); ok {
+					if _, exists := commandVars[fn.Name.Name]; !exists {
+						commandVars[fn.Name.Name] = id.Name
+					}
+				}
+			}
+			if id, ok := call.Fun.(*ast.Ident); ok && id.Name == "addSubCommand" && len(call.Args) == 2 {
+				if sub, ok := call.Args[1].(*ast.CallExpr); ok {
+					if fid, ok := sub.Fun.(*ast.Ident); ok {
+						constructed = append(constructed, fid.Name)
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	var commands []string
+	for _, fn := range constructed {
+		name, ok := names[fn]
+		if !ok {
+			name, ok = endpoints[commandVars[fn]]
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s: no happy.NewCommand call or endpoint command in %s", dir, fn)
+		}
+		commands = append(commands, name)
+	}
+	sort.Strings(commands)
+	return commands, nil
+}
+
+// endpointName returns name of endpoint definition when expr is endpoint
+// composite literal with name given as string literal.
+func endpointName(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return "", false
+	}
+	if id, ok := lit.Type.(*ast.Ident); !ok || id.Name != "endpoint" {
+		return "", false
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); !ok || key.Name != "name" {
+			continue
+		}
+		if value, ok := kv.Value.(*ast.BasicLit); ok && value.Kind == token.STRING {
+			name, err := strconv.Unquote(value.Value)
+			return name, err == nil
+		}
+	}
+	return "", false
+}
+
+// newCommandName returns name of the command when call is happy.NewCommand
+// call with name given as string literal.
+func newCommandName(call *ast.CallExpr) (string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "NewCommand" || len(call.Args) == 0 {
+		return "", false
+	}
+	if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "happy" {
+		return "", false
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	name, err := strconv.Unquote(lit.Value)
+	return name, err == nil
+}
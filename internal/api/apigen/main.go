@@ -0,0 +1,389 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+// Command apigen generates definitions of api commands from the vendored
+// Koios OpenAPI specification. It is run by go generate in internal/api.
+//
+//	go run ./apigen [-spec file] [-out file]
+//
+// Each endpoint gets definition named after its path, e.g. endpointTxInfo
+// for /tx_info. Description and info of the command are summary and
+// description of the endpoint and category is its tag, unless overridden
+// in categories.
+//
+// String query params become positional arguments, other query params and
+// options of request body become flags. Array of request body becomes
+// variadic argument, which is split by the command into batches of at most
+// maxItems of the array, or batchSizes of the endpoint when the spec does
+// not limit the array. Epoch number is flag when endpoint has other
+// positional arguments.
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const header = `// Code generated by apigen from koiosapi.yaml; DO NOT EDIT.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/happy-sdk/happy/pkg/vars/varflag"
+)
+`
+
+// flagParams are params kept as flags where they would be positional
+// arguments, so commands keep flags they had before being generated.
+var flagParams = map[string]string{
+	"/totals":             "_epoch_no",
+	"/drep_epoch_summary": "_epoch_no",
+}
+
+// categories are tags of endpoints, which commands are listed in other
+// category than the spec tags them with.
+var categories = map[string]string{
+	"/pool_voting_power_history": "Pool",
+}
+
+// defaultBatchSize is number of items of request body array sent per
+// request when neither the spec nor batchSizes limit the array.
+const defaultBatchSize = 50
+
+// batchSizes are numbers of items of request body array accepted per
+// request by endpoints, which accept other number than defaultBatchSize.
+var batchSizes = map[string]int{
+	"/address_info": 100,
+}
+
+// sharedFlags are flags declared in internal/api, used by params which
+// need other name, alias or default value than derived from the spec.
+var sharedFlags = map[string]struct{ fn, name string }{
+	"_epoch_no": {"epochNoFlag", "epoch"},
+	"_extended": {"extendedFlag", "extended"},
+}
+
+type spec struct {
+	Paths      yaml.Node `yaml:"paths"`
+	Components struct {
+		Parameters    map[string]parameter   `yaml:"parameters"`
+		RequestBodies map[string]requestBody `yaml:"requestBodies"`
+	} `yaml:"components"`
+}
+
+type operation struct {
+	Tags        []string     `yaml:"tags"`
+	Summary     string       `yaml:"summary"`
+	Description string       `yaml:"description"`
+	Parameters  []parameter  `yaml:"parameters"`
+	RequestBody *requestBody `yaml:"requestBody"`
+}
+
+type parameter struct {
+	Ref         string `yaml:"$ref"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+	Schema      schema `yaml:"schema"`
+}
+
+type requestBody struct {
+	Ref     string `yaml:"$ref"`
+	Content map[string]struct {
+		Schema schema `yaml:"schema"`
+	} `yaml:"content"`
+}
+
+type schema struct {
+	Type        string    `yaml:"type"`
+	MaxItems    int       `yaml:"maxItems"`
+	Description string    `yaml:"description"`
+	Required    []string  `yaml:"required"`
+	Properties  yaml.Node `yaml:"properties"`
+}
+
+// param is query param or property of request body of the endpoint.
+type param struct {
+	name     string
+	desc     string
+	typ      string
+	required bool
+	maxItems int
+}
+
+// command is definition of api command generated for the endpoint.
+type command struct {
+	path        string
+	method      string
+	category    string
+	description string
+	info        string
+	args        []string
+	argnMin     int
+	variadic    bool
+	batchSize   int
+	flags       []string
+	params      [][2]string
+}
+
+func main() {
+	specFile := flag.String("spec", "koiosapi.yaml", "Koios OpenAPI specification")
+	out := flag.String("out", "endpoints_gen.go", "Output file")
+	flag.Parse()
+
+	commands, err := readSpec(*specFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	for _, cmd := range commands {
+		cmd.write(&buf)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// readSpec returns command definitions of endpoints in order of the spec.
+func readSpec(file string) ([]command, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var s spec
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	var commands []command
+	for i := 0; i+1 < len(s.Paths.Content); i += 2 {
+		path := s.Paths.Content[i].Value
+		methods := s.Paths.Content[i+1]
+		for j := 0; j+1 < len(methods.Content); j += 2 {
+			var op operation
+			if err := methods.Content[j+1].Decode(&op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", file, path, err)
+			}
+			cmd, err := s.command(path, strings.ToUpper(methods.Content[j].Value), op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", file, path, err)
+			}
+			commands = append(commands, cmd)
+		}
+	}
+	return commands, nil
+}
+
+// command returns definition of api command of the endpoint.
+func (s spec) command(path, method string, op operation) (command, error) {
+	cmd := command{
+		path:        path,
+		method:      method,
+		description: op.Summary,
+		info:        op.Description,
+	}
+	if len(op.Tags) == 0 {
+		return cmd, fmt.Errorf("endpoint has no tag")
+	}
+	tag := op.Tags[0]
+	if category, ok := categories[path]; ok {
+		tag = category
+	}
+	cmd.category = "category" + strings.ReplaceAll(tag, " ", "")
+
+	var query []param
+	for _, p := range op.Parameters {
+		if p.Ref != "" {
+			ref, ok := s.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+			if !ok {
+				return cmd, fmt.Errorf("unknown parameter %s", p.Ref)
+			}
+			p = ref
+		}
+		query = append(query, param{name: p.Name, desc: p.Description, typ: p.Schema.Type, required: p.Required})
+	}
+	body, err := s.body(op.RequestBody)
+	if err != nil {
+		return cmd, err
+	}
+
+	var positional int
+	for _, p := range query {
+		if p.typ == "string" && flagParams[path] != p.name {
+			positional++
+		}
+	}
+	var policy, assetName bool
+	for _, p := range query {
+		isArg := p.typ == "string" && flagParams[path] != p.name
+		if p.name == "_epoch_no" && positional > 1 {
+			isArg = false
+		}
+		if !isArg {
+			cmd.addFlag(p)
+			continue
+		}
+		cmd.args = append(cmd.args, p.name)
+		if p.required {
+			cmd.argnMin++
+		}
+		policy = policy || p.name == "_asset_policy"
+		assetName = assetName || p.name == "_asset_name"
+	}
+	// asset can be given as single policy_id.asset_name argument
+	if policy && assetName {
+		cmd.argnMin = 1
+	}
+	for _, p := range body {
+		if p.typ == "array" {
+			cmd.args = append(cmd.args, p.name)
+			cmd.variadic = true
+			cmd.batchSize = p.maxItems
+			if cmd.batchSize == 0 {
+				cmd.batchSize = cmp.Or(batchSizes[path], defaultBatchSize)
+			}
+			continue
+		}
+		cmd.addFlag(p)
+	}
+	return cmd, nil
+}
+
+// body returns params of json request body. Only properties prefixed with
+// underscore are params, as in query params of the api.
+func (s spec) body(rb *requestBody) ([]param, error) {
+	if rb == nil {
+		return nil, nil
+	}
+	if rb.Ref != "" {
+		ref, ok := s.Components.RequestBodies[strings.TrimPrefix(rb.Ref, "#/components/requestBodies/")]
+		if !ok {
+			return nil, fmt.Errorf("unknown request body %s", rb.Ref)
+		}
+		rb = &ref
+	}
+	content, ok := rb.Content["application/json"]
+	if !ok {
+		return nil, nil
+	}
+
+	required := make(map[string]bool)
+	for _, name := range content.Schema.Required {
+		required[name] = true
+	}
+	var params []param
+	props := content.Schema.Properties.Content
+	for i := 0; i+1 < len(props); i += 2 {
+		name := props[i].Value
+		if !strings.HasPrefix(name, "_") {
+			continue
+		}
+		var prop schema
+		if err := props[i+1].Decode(&prop); err != nil {
+			return nil, fmt.Errorf("property %s: %w", name, err)
+		}
+		params = append(params, param{
+			name:     name,
+			desc:     prop.Description,
+			typ:      prop.Type,
+			required: required[name],
+			maxItems: prop.MaxItems,
+		})
+	}
+	return params, nil
+}
+
+// addFlag adds flag of the param, named as the param without underscores.
+func (cmd *command) addFlag(p param) {
+	var fn string
+	name := strings.ReplaceAll(strings.TrimPrefix(p.name, "_"), "_", "-")
+	shared, ok := sharedFlags[p.name]
+	switch {
+	case ok:
+		fn, name = shared.fn, shared.name
+	case p.typ == "boolean":
+		fn = fmt.Sprintf("varflag.BoolFunc(%q, false, %q)", name, p.desc)
+	case p.typ == "integer":
+		fn = fmt.Sprintf("varflag.UintFunc(%q, 0, %q)", name, p.desc)
+	default:
+		fn = fmt.Sprintf("varflag.StringFunc(%q, \"\", %q)", name, p.desc)
+	}
+	cmd.flags = append(cmd.flags, fn)
+	cmd.params = append(cmd.params, [2]string{name, p.name})
+}
+
+func (cmd command) write(buf *bytes.Buffer) {
+	name := strings.TrimPrefix(cmd.path, "/")
+	method := "http.Method" + cmd.method[:1] + strings.ToLower(cmd.method[1:])
+
+	usage := "koios api " + name
+	for _, arg := range cmd.args {
+		if cmd.variadic {
+			arg += "..."
+		}
+		usage += " [" + arg + "]"
+	}
+
+	fmt.Fprintf(buf, "\n// %s is definition of %s %s command.\n", varName(name), cmd.method, cmd.path)
+	fmt.Fprintf(buf, "var %s = endpoint{\n", varName(name))
+	fmt.Fprintf(buf, "name: %q,\n", name)
+	fmt.Fprintf(buf, "method: %s,\n", method)
+	fmt.Fprintf(buf, "category: %s,\n", cmd.category)
+	fmt.Fprintf(buf, "description: %q,\n", cmd.description)
+	fmt.Fprintf(buf, "info: %q,\n", cmd.info)
+	fmt.Fprintf(buf, "usage: %q,\n", usage)
+	if cmd.argnMin > 0 {
+		fmt.Fprintf(buf, "argnMin: %d,\n", cmd.argnMin)
+	}
+	switch {
+	case cmd.variadic:
+		buf.WriteString("argnMax: batchArgnMax,\n")
+		fmt.Fprintf(buf, "batchSize: %d,\n", cmd.batchSize)
+	case len(cmd.args) > 0:
+		fmt.Fprintf(buf, "argnMax: %d,\n", len(cmd.args))
+	}
+	if len(cmd.flags) > 0 {
+		buf.WriteString("flags: []varflag.FlagCreateFunc{\n")
+		for _, fn := range cmd.flags {
+			buf.WriteString(fn + ",\n")
+		}
+		buf.WriteString("},\n")
+		buf.WriteString("params: map[string]string{\n")
+		for _, p := range cmd.params {
+			fmt.Fprintf(buf, "%q: %q,\n", p[0], p[1])
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+}
+
+// varName returns name of endpoint definition, e.g. endpointTxInfo.
+func varName(name string) string {
+	var b strings.Builder
+	b.WriteString("endpoint")
+	for _, part := range strings.Split(name, "_") {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
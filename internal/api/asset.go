@@ -5,6 +5,7 @@
 package api
 
 import (
+	"context"
 	"slices"
 	"strings"
 
@@ -20,33 +21,24 @@ const categoryAsset = "asset"
 func asset(cmd *happy.Command, c *client) {
 	cmd.DescribeCategory(categoryAsset, "Query Asset related informations")
 
-	cmd.AddSubCommand(cmdAssetAddresses(c))
-	cmd.AddSubCommand(cmdAssetHistory(c))
-	cmd.AddSubCommand(cmdAssetInfo(c))
-	cmd.AddSubCommand(cmdAssetList(c))
-	cmd.AddSubCommand(cmdAssetNftAddress(c))
-	cmd.AddSubCommand(cmdAssetSummary(c))
-	cmd.AddSubCommand(cmdAssetTokenRegistry(c))
-	cmd.AddSubCommand(cmdAssetTxs(c))
-	cmd.AddSubCommand(cmdAssetUtxos(c))
-	cmd.AddSubCommand(cmdAssetPolicyAssetAddresses(c))
-	cmd.AddSubCommand(cmdAssetPolicyAssetInfo(c))
-	cmd.AddSubCommand(cmdAssetPolicyAssetList(c))
-	cmd.AddSubCommand(cmdAssetPolicyAssetMints(c))
+	addSubCommand(cmd, cmdAssetAddresses(c))
+	addSubCommand(cmd, cmdAssetHistory(c))
+	addSubCommand(cmd, cmdAssetInfo(c))
+	addSubCommand(cmd, cmdAssetList(c))
+	addSubCommand(cmd, cmdAssetNftAddress(c))
+	addSubCommand(cmd, cmdAssetSummary(c))
+	addSubCommand(cmd, cmdAssetTokenRegistry(c))
+	addSubCommand(cmd, cmdAssetTxs(c))
+	addSubCommand(cmd, cmdAssetUtxos(c))
+	addSubCommand(cmd, cmdAssetPolicyAssetAddresses(c))
+	addSubCommand(cmd, cmdAssetPolicyAssetInfo(c))
+	addSubCommand(cmd, cmdAssetPolicyAssetList(c))
+	addSubCommand(cmd, cmdAssetPolicyAssetMints(c))
 }
 
 func cmdAssetAddresses(c *client) *happy.Command {
-	cmd := happy.NewCommand("asset_addresses",
-		happy.Option("description", "Asset Addresses"),
-		happy.Option("category", categoryAsset),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 1),
-		happy.Option("usage", "koios api asset_addresses [policy_id].[asset_name]"),
-	)
-	cmd.AddInfo("Get the list of all addresses holding a given asset.")
+	cmd := endpointAssetAddresses.command()
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#get-/asset_addresses
-
     Example: koios-cli api asset_addresses 750900e4999ebe0d58f19b634768ba25e525aaf12403bfe8fe130501.424f4f4b
   `)
 
@@ -55,27 +47,20 @@ func cmdAssetAddresses(c *client) *happy.Command {
 		if err != nil {
 			return err
 		}
-		policy, asset, _ := strings.Cut(args.Arg(0).String(), ".")
-		res, err := c.koios().GetAssetAddresses(sess, koios.PolicyID(policy), koios.AssetName(asset), opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		policy, asset, err := assetArg(args)
+		if err != nil {
+			return err
+		}
+		res, err := c.koios().GetAssetAddresses(sess, policy, asset, opts)
+		return c.output(res, err)
 	})
 
 	return cmd
 }
 
 func cmdAssetHistory(c *client) *happy.Command {
-	cmd := happy.NewCommand("asset_history",
-		happy.Option("description", "Asset History"),
-		happy.Option("category", categoryAsset),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 1),
-		happy.Option("usage", "koios api asset_history [policy_id].[asset_name]"),
-	)
-	cmd.AddInfo("Get the mint/burn history of an asset.")
+	cmd := endpointAssetHistory.command()
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/asset_history
-
   Example: koios-cli api asset_history 750900e4999ebe0d58f19b634768ba25e525aaf12403bfe8fe130501.424f4f4b
   `)
 
@@ -84,63 +69,37 @@ func cmdAssetHistory(c *client) *happy.Command {
 		if err != nil {
 			return err
 		}
-		policy, asset, _ := strings.Cut(args.Arg(0).String(), ".")
-		res, err := c.koios().GetAssetHistory(sess, koios.PolicyID(policy), koios.AssetName(asset), opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		policy, asset, err := assetArg(args)
+		if err != nil {
+			return err
+		}
+		res, err := c.koios().GetAssetHistory(sess, policy, asset, opts)
+		return c.output(res, err)
 	})
 
 	return cmd
 }
 
 func cmdAssetInfo(c *client) *happy.Command {
-	cmd := happy.NewCommand("asset_info",
-		happy.Option("description", "Asset Information (Bulk)"),
-		happy.Option("category", categoryAsset),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("Get the information of an asset including first minting & token registry metadata.")
+	cmd := endpointAssetInfo.command().WithFlags(slices.Concat(pagingFlags, flagSlice(fromFileFlag))...)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#post-/asset_info
-
   Example: koios-cli api asset_info \
     750900e4999ebe0d58f19b634768ba25e525aaf12403bfe8fe130501.424f4f4b \
     f0ff48bbb7bbe9d59a40f1ce90e9e9d0ff5002ec48f232b49ca0fb9a.6b6f696f732e72657374
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		var assets []koios.Asset
-		for _, arg := range args.Args() {
-			policy, asset, _ := strings.Cut(arg.String(), ".")
-			assets = append(assets, koios.Asset{
-				PolicyID:  koios.PolicyID(policy),
-				AssetName: koios.AssetName(asset),
-			})
-		}
-
-		res, err := c.koios().GetAssetInfo(sess, assets, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.batch(sess, args, endpointAssetInfo, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetAssetInfo(ctx, parseAssets(batch), opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdAssetList(c *client) *happy.Command {
-	cmd := happy.NewCommand("asset_list",
-		happy.Option("description", "Asset List"),
-		happy.Option("category", categoryAsset),
-	).WithFlags(pagingFlags...)
-	cmd.AddInfo("Get the list of all native assets (paginated)")
+	cmd := endpointAssetList.command().WithFlags(pagingFlags...)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/asset_list
-
   Example: koios-cli api asset_list
     {
       ...
@@ -157,60 +116,44 @@ func cmdAssetList(c *client) *happy.Command {
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		res, err := c.koios().GetAssets(sess, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetAssets(ctx, opts)
+		})
 	})
 	return cmd
 }
 
 func cmdAssetNftAddress(c *client) *happy.Command {
-	cmd := happy.NewCommand("asset_nft_address",
-		happy.Option("description", "Asset NFT Address"),
-		happy.Option("category", categoryAsset),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 1),
-		happy.Option("usage", "koios api asset_nft_address [policy_id].[asset_name]"),
-	)
-	cmd.AddInfo("Get the address where specified NFT currently reside on.")
+	cmd := endpointAssetNftAddress.command()
 
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/asset_nft_address
+  Asset can be given as policy_id.asset_name, as policy_id and asset_name
+  arguments or as asset unit, policy_id and asset_name concatenated.
 
   Example: koios-cli api asset_nft_address f0ff48bbb7bbe9d59a40f1ce90e9e9d0ff5002ec48f232b49ca0fb9a.68616e646c65
+  Example: koios-cli api asset_nft_address f0ff48bbb7bbe9d59a40f1ce90e9e9d0ff5002ec48f232b49ca0fb9a 68616e646c65
+  Example: koios-cli api asset_nft_address f0ff48bbb7bbe9d59a40f1ce90e9e9d0ff5002ec48f232b49ca0fb9a68616e646c65
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		policy, asset, err := assetArg(args)
+		if err != nil {
+			return err
+		}
 		opts, err := c.newRequestOpts(sess, args)
 		if err != nil {
 			return err
 		}
-		policy, asset, _ := strings.Cut(args.Arg(0).String(), ".")
-		res, err := c.koios().GetAssetNftAddress(sess, koios.PolicyID(policy), koios.AssetName(asset), opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		res, err := c.koios().GetAssetNftAddress(sess, policy, asset, opts)
+		return c.output(res, err)
 	})
 
 	return cmd
 }
 
 func cmdAssetSummary(c *client) *happy.Command {
-	cmd := happy.NewCommand("asset_summary",
-		happy.Option("description", "Asset Summary"),
-		happy.Option("category", categoryAsset),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 1),
-		happy.Option("usage", "koios api asset_summary [policy_id].[asset_name]"),
-	)
-
-	cmd.AddInfo("Get the summary of an asset (total transactions exclude minting/total wallets include only wallets with asset balance)")
+	cmd := endpointAssetSummary.command()
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/asset_summary
-
   Example: koios-cli api asset_summary 750900e4999ebe0d58f19b634768ba25e525aaf12403bfe8fe130501.424f4f4b
   `)
 
@@ -219,134 +162,78 @@ func cmdAssetSummary(c *client) *happy.Command {
 		if err != nil {
 			return err
 		}
-		policy, asset, _ := strings.Cut(args.Arg(0).String(), ".")
-		res, err := c.koios().GetAssetSummary(sess, koios.PolicyID(policy), koios.AssetName(asset), opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		policy, asset, err := assetArg(args)
+		if err != nil {
+			return err
+		}
+		res, err := c.koios().GetAssetSummary(sess, policy, asset, opts)
+		return c.output(res, err)
 	})
 
 	return cmd
 }
 
 func cmdAssetTokenRegistry(c *client) *happy.Command {
-	cmd := happy.NewCommand("asset_token_registry",
-		happy.Option("description", "Asset Token Registry"),
-		happy.Option("category", categoryAsset),
-	).WithFlags(pagingFlags...)
-	cmd.AddInfo("Get a list of assets registered via token registry on github")
+	cmd := endpointAssetTokenRegistry.command().WithFlags(pagingFlags...)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/asset_token_registry
-
   Example: koios-cli api asset_token_registry
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		res, err := c.koios().GetAssetTokenRegistry(sess, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetAssetTokenRegistry(ctx, opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdAssetTxs(c *client) *happy.Command {
-	cmd := happy.NewCommand("asset_txs",
-		happy.Option("description", "Asset Transactions"),
-		happy.Option("category", categoryAsset),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 1),
-		happy.Option("usage", "koios api asset_txs policy_id[.asset_name]"),
-	).WithFlags(
-		slices.Concat(
-			pagingFlags,
-			flagSlice(
-				afterBlockHeightFlag,
-				varflag.BoolFunc("history", false, "Include all historical transactions, setting to false includes only the non-empty ones"),
-			),
-		)...)
-
-	cmd.AddInfo("Get the list of all asset transaction hashes (newest first).")
+	cmd := endpointAssetTxs.command().WithFlags(pagingFlags...)
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#get-/asset_txs
-
     Example: koios-cli api asset_txs 750900e4999ebe0d58f19b634768ba25e525aaf12403bfe8fe130501.424f4f4b \
       --after-block-height 50000 \
       --history
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
+		policy, asset, err := assetArg(args)
 		if err != nil {
 			return err
 		}
-		policy, asset, _ := strings.Cut(args.Arg(0).String(), ".")
-		res, err := c.koios().GetAssetTxs(
-			sess,
-			koios.PolicyID(policy),
-			koios.AssetName(asset),
-			args.Flag("after-block-height").Var().Uint(),
-			args.Flag("history").Var().Bool(),
-			opts,
-		)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetAssetTxs(
+				ctx,
+				policy,
+				asset,
+				args.Flag("after-block-height").Var().Uint(),
+				args.Flag("history").Var().Bool(),
+				opts,
+			)
+		})
 	})
 
 	return cmd
 }
 
 func cmdAssetUtxos(c *client) *happy.Command {
-	cmd := happy.NewCommand("asset_utxos",
-		happy.Option("description", "Asset UTXOs"),
-		happy.Option("category", categoryAsset),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("Get the UTXO information of a list of assets including")
+	cmd := endpointAssetUtxos.command().WithFlags(slices.Concat(pagingFlags, flagSlice(fromFileFlag))...)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#post-/asset_utxos
-
   Example: koios-cli api asset_utxos \
     750900e4999ebe0d58f19b634768ba25e525aaf12403bfe8fe130501.424f4f4b \
     f0ff48bbb7bbe9d59a40f1ce90e9e9d0ff5002ec48f232b49ca0fb9a.6b6f696f732e72657374
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		var assets []koios.Asset
-		for _, arg := range args.Args() {
-			policy, asset, _ := strings.Cut(arg.String(), ".")
-			assets = append(assets, koios.Asset{
-				PolicyID:  koios.PolicyID(policy),
-				AssetName: koios.AssetName(asset),
-			})
-		}
-
-		res, err := c.koios().GetAssetUTxOs(sess, assets, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.batch(sess, args, endpointAssetUtxos, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.post(ctx, "/asset_utxos", endpointAssetUtxos.payload(args, "_asset_list", assetList(batch)), opts)
+		})
 	})
 	return cmd
 }
 
 func cmdAssetPolicyAssetAddresses(c *client) *happy.Command {
-	cmd := happy.NewCommand("policy_asset_addresses",
-		happy.Option("description", "Policy Asset Address List"),
-		happy.Option("category", categoryAsset),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 1),
-		happy.Option("usage", "koios api policy_asset_addresses [policy_id]"),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("Get the list of addresses with quantity for each asset on the given policy")
+	cmd := endpointPolicyAssetAddresses.command().WithFlags(pagingFlags...)
 
 	cmd.AddInfo(`
   Note - Due to cardano's UTxO design and usage from projects, asset to addresses map can be infinite.
@@ -356,109 +243,116 @@ func cmdAssetPolicyAssetAddresses(c *client) *happy.Command {
   `)
 
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/policy_asset_addresses
-
   Example: koios-cli api policy_asset_addresses 750900e4999ebe0d58f19b634768ba25e525aaf12403bfe8fe130501
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		res, err := c.koios().GetPolicyAssetAddresses(sess, koios.PolicyID(args.Arg(0).String()), opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetPolicyAssetAddresses(ctx, koios.PolicyID(args.Arg(0).String()), opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdAssetPolicyAssetInfo(c *client) *happy.Command {
-	cmd := happy.NewCommand("policy_asset_info",
-		happy.Option("description", "Policy Asset Information"),
-		happy.Option("category", categoryAsset),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 1),
-		happy.Option("usage", "koios api policy_asset_info [policy_id]"),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("Get the information for all assets under the same policy")
+	cmd := endpointPolicyAssetInfo.command().WithFlags(pagingFlags...)
 
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/policy_asset_info
-
   Example: koios-cli api policy_asset_info 750900e4999ebe0d58f19b634768ba25e525aaf12403bfe8fe130501
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		res, err := c.koios().GetPolicyAssetInfo(sess, koios.PolicyID(args.Arg(0).String()), opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetPolicyAssetInfo(ctx, koios.PolicyID(args.Arg(0).String()), opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdAssetPolicyAssetList(c *client) *happy.Command {
-	cmd := happy.NewCommand("policy_asset_list",
-		happy.Option("description", "Policy Asset List"),
-		happy.Option("category", categoryAsset),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 1),
-		happy.Option("usage", "koios api policy_asset_list [policy_id]"),
-	).WithFlags(pagingFlags...)
-	cmd.AddInfo("Get the list of all assets minted under a given policy (paginated)")
+	cmd := endpointPolicyAssetList.command().WithFlags(pagingFlags...)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/policy_asset_list
-
   Example: koios-cli api policy_asset_list 750900e4999ebe0d58f19b634768ba25e525aaf12403bfe8fe130501
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return nil
-		}
-		res, err := c.koios().GetPolicyAssetList(sess, koios.PolicyID(args.Arg(0).String()), opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetPolicyAssetList(ctx, koios.PolicyID(args.Arg(0).String()), opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdAssetPolicyAssetMints(c *client) *happy.Command {
-	cmd := happy.NewCommand("policy_asset_mints",
-		happy.Option("description", "Policy Asset Mints"),
-		happy.Option("category", categoryAsset),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 1),
-		happy.Option("usage", "koios api policy_asset_mints [_asset_policy]"),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("Get a list of mint or burn count details for all assets minted under a policy")
+	cmd := endpointPolicyAssetMints.command().WithFlags(
+		slices.Concat(
+			pagingFlags,
+			flagSlice(
+				queryFlag,
+				varflag.StringFunc("asset-name", "", "Only list mints of asset with hex encoded asset name"),
+			),
+		)...,
+	)
 
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#get-/policy_asset_mints
-
     Example: koios-cli api policy_asset_mints 313534a537bc476c86ff7c57ec511bd7f24a9d15654091b24e9c606e
     Example: koios-cli api policy_asset_mints 313534a537bc476c86ff7c57ec511bd7f24a9d15654091b24e9c606e --page 1 --page-size 3
+    Example: koios-cli api policy_asset_mints 313534a537bc476c86ff7c57ec511bd7f24a9d15654091b24e9c606e --asset-name 41484c636f696e
+    Example: koios-cli api policy_asset_mints 313534a537bc476c86ff7c57ec511bd7f24a9d15654091b24e9c606e --query "burn_cnt=gt.0&order=creation_time.desc"
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		res, err := c.koios().GetPolicyAssetMints(sess, koios.PolicyID(args.Arg(0).String()), opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			if name := args.Flag("asset-name").String(); name != "" {
+				opts.QuerySet("asset_name", "eq."+name)
+			}
+			return c.koios().GetPolicyAssetMints(ctx, koios.PolicyID(args.Arg(0).String()), opts)
+		})
 	})
 
 	return cmd
 }
+
+// parseAssets parses assets given as policy_id.asset_name.
+func parseAssets(values []string) []koios.Asset {
+	var assets []koios.Asset
+	for _, v := range values {
+		policy, asset, _ := strings.Cut(v, ".")
+		assets = append(assets, koios.Asset{
+			PolicyID:  koios.PolicyID(policy),
+			AssetName: koios.AssetName(asset),
+		})
+	}
+	return assets
+}
+
+// assetList returns _asset_list param of assets given as policy_id.asset_name.
+func assetList(values []string) [][]string {
+	var list [][]string
+	for _, asset := range parseAssets(values) {
+		list = append(list, []string{asset.PolicyID.String(), asset.AssetName.String()})
+	}
+	return list
+}
+
+// policyIDLen is length of hex encoded policy id.
+const policyIDLen = 56
+
+// assetArg parses asset given as policy_id.asset_name, as policy_id and
+// asset_name arguments or as asset unit, policy_id and asset_name concatenated.
+func assetArg(args happy.Args) (koios.PolicyID, koios.AssetName, error) {
+	if args.Argn() > 1 {
+		return koios.PolicyID(args.Arg(0).String()), koios.AssetName(args.Arg(1).String()), nil
+	}
+	arg := args.Arg(0).String()
+	if policy, asset, ok := strings.Cut(arg, "."); ok {
+		return koios.PolicyID(policy), koios.AssetName(asset), nil
+	}
+	if len(arg) < policyIDLen {
+		return "", "", usageErrorf("invalid asset %q, expected policy_id.asset_name", arg)
+	}
+	return koios.PolicyID(arg[:policyIDLen]), koios.AssetName(arg[policyIDLen:]), nil
+}
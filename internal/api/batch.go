@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+
+	"github.com/cardano-community/koios-go-client/v4"
+	"github.com/happy-sdk/happy"
+)
+
+const (
+	// batchArgnMax is max argument count of commands which
+	// split arguments into api sized batches.
+	batchArgnMax = 100000
+
+	defaultConcurrency = 4
+)
+
+// batchFunc requests single page for batch of command arguments.
+type batchFunc func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error)
+
+// batch reads command arguments, see argValues, and splits them into batches
+// of max arguments accepted by endpoint e per request.
+// Batches are requested concurrently, at most --concurrency at a time, and all
+// requests go through the client rate limiter. Responses are merged into single response in input order.
+//
+// When some of the batches fail, each failed batch is logged and records of
+// successful batches are still written before error is returned.
+func (c *client) batch(sess *happy.Session, args happy.Args, e endpoint, fetch batchFunc) error {
+	limit := e.batchSize
+	values, err := argValues(args)
+	if err != nil {
+		return categorize(errCodeUsage, err)
+	}
+	if len(values) == 0 {
+		return usageErrorf("no arguments, provide them as arguments, with --from-file or with - to read from stdin")
+	}
+	if len(values) <= limit {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return fetch(ctx, values, opts)
+		})
+	}
+
+	var batches [][]string
+	for len(values) > 0 {
+		n := min(limit, len(values))
+		batches = append(batches, values[:n])
+		values = values[n:]
+	}
+
+	// each batch needs at least one request
+	if err := c.reserveRequests(uint(len(batches))); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(sess, os.Interrupt)
+	defer stop()
+
+	sess.Log().Debug("splitting arguments into batches",
+		slog.Int("batches", len(batches)),
+		slog.Int("batch-size", limit),
+		slog.Uint64("concurrency", uint64(c.concurrency)),
+	)
+
+	var (
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, max(c.concurrency, 1))
+		results = make([]any, len(batches))
+		errs    = make([]error, len(batches))
+	)
+	for i, b := range batches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if err := ctx.Err(); err != nil {
+				errs[i] = err
+				return
+			}
+			sess.Log().Debug("requesting batch", slog.Int("batch", i+1), slog.Int("size", len(b)))
+			_, _, errs[i] = c.fetchPages(ctx, sess, args,
+				func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+					return fetch(ctx, b, opts)
+				},
+				func(res any) (int, bool, error) {
+					n, ok := appendResponseData(&results[i], res)
+					return n, ok, nil
+				},
+			)
+		}()
+	}
+	wg.Wait()
+
+	var (
+		merged any
+		failed []error
+	)
+	for i, b := range batches {
+		if errs[i] != nil {
+			sess.Log().Error("batch failed",
+				slog.Int("batch", i+1),
+				slog.Int("batches", len(batches)),
+				slog.String("first", b[0]),
+				slog.String("last", b[len(b)-1]),
+				slog.String("err", errs[i].Error()),
+			)
+			failed = append(failed, fmt.Errorf("batch %d/%d: %w", i+1, len(batches), errs[i]))
+			continue
+		}
+		if results[i] != nil {
+			appendResponseData(&merged, results[i])
+		}
+	}
+
+	if ctx.Err() != nil {
+		sess.Log().Warn("interrupted, output contains only batches received so far")
+	}
+	if len(failed) == len(batches) {
+		err := errors.Join(failed...)
+		apiOutput(c.out, nil, err)
+		return err
+	}
+	apiOutput(c.out, merged, nil)
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d batches failed: %w", len(failed), len(batches), errors.Join(failed...))
+	}
+	return nil
+}
+
+// argValues returns command arguments together with arguments read from
+// --from-file file and from stdin when "-" is given as argument.
+func argValues(args happy.Args) ([]string, error) {
+	var values []string
+	if args.Flag("from-file").Present() {
+		data, err := os.ReadFile(args.Flag("from-file").String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read arguments: %w", err)
+		}
+		v, err := parseArgValues(data)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v...)
+	}
+
+	var stdin bool
+	for _, arg := range args.Args() {
+		if arg.String() != "-" {
+			values = append(values, arg.String())
+			continue
+		}
+		if stdin {
+			return nil, errors.New("arguments can be read from stdin only once")
+		}
+		stdin = true
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read arguments from stdin: %w", err)
+		}
+		v, err := parseArgValues(data)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v...)
+	}
+	return values, nil
+}
+
+// parseArgValues parses arguments separated by newline or comma,
+// or given as json array of strings.
+func parseArgValues(data []byte) ([]string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var values []string
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse arguments json array: %w", err)
+		}
+		return values, nil
+	}
+
+	var values []string
+	for _, v := range strings.FieldsFunc(string(data), func(r rune) bool {
+		return r == '\n' || r == ','
+	}) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// argsOf converts batch of arguments to koios api type.
+func argsOf[T ~string](values []string) []T {
+	res := make([]T, 0, len(values))
+	for _, v := range values {
+		res = append(res, T(v))
+	}
+	return res
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseArgValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		values []string
+		err    string
+	}{
+		{name: "newline", data: "addr1\naddr2\n", values: []string{"addr1", "addr2"}},
+		{name: "crlf", data: "addr1\r\naddr2\r\n", values: []string{"addr1", "addr2"}},
+		{name: "comma", data: "addr1,addr2", values: []string{"addr1", "addr2"}},
+		{name: "comma and newline", data: "addr1, addr2,\naddr3\n", values: []string{"addr1", "addr2", "addr3"}},
+		{name: "blank lines and spaces", data: "\n  addr1  \n\n\taddr2\n,,\n", values: []string{"addr1", "addr2"}},
+		{name: "single value", data: "addr1", values: []string{"addr1"}},
+		{name: "json array", data: `["addr1","addr2"]`, values: []string{"addr1", "addr2"}},
+		{name: "json array with whitespace", data: "\n [\n  \"addr1\",\n  \"addr2\"\n ]\n", values: []string{"addr1", "addr2"}},
+		{name: "json array with comma in value", data: `["a,b"]`, values: []string{"a,b"}},
+		{name: "empty json array", data: `[]`, values: []string{}},
+		{name: "empty", data: " \n ", values: nil},
+		{name: "invalid json array", data: `["addr1",`, err: "failed to parse arguments json array"},
+		{name: "json array of numbers", data: `[1,2]`, err: "failed to parse arguments json array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := parseArgValues([]byte(tt.data))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("parseArgValues() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseArgValues() error: %v", err)
+			}
+			if !reflect.DeepEqual(values, tt.values) {
+				t.Errorf("parseArgValues() = %q, want %q", values, tt.values)
+			}
+		})
+	}
+}
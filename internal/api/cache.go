@@ -0,0 +1,383 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/happy-sdk/happy/sdk/logging"
+)
+
+const (
+	cacheModeOff     = "off"
+	cacheModeRead    = "read"
+	cacheModeRefresh = "refresh"
+
+	// cacheForever is ttl of responses which never change.
+	cacheForever time.Duration = -1
+	// cacheTTL is ttl of found records which can not be proven final.
+	cacheTTL = 5 * time.Minute
+	// cacheConfirmedAge is age of blocks which can no longer roll back,
+	// security parameter k of 2160 blocks produced every 20 seconds.
+	cacheConfirmedAge = 12 * time.Hour
+
+	// cacheMaxAge is how long cached response is kept since it was last used.
+	cacheMaxAge = 30 * 24 * time.Hour
+	// cacheMaxSize is max size of the cache directory in bytes,
+	// least recently used responses are removed above it.
+	cacheMaxSize = 256 << 20
+)
+
+var (
+	cacheModes = []string{cacheModeOff, cacheModeRead, cacheModeRefresh}
+
+	errCacheMiss = errors.New("response not found in cache")
+)
+
+// cacheRule returns how long response of the endpoint is cached, 0 when
+// it is not cached and cacheForever when it will never change. Endpoints
+// without rule are not cached, as their responses change with the chain.
+type cacheRule func(req cacheRequest, records []map[string]json.RawMessage) time.Duration
+
+type cacheRequest struct {
+	query url.Values
+	body  []byte
+}
+
+var cacheRules = map[string]cacheRule{
+	"genesis":      always(cacheForever),
+	"epoch_info":   when(epochEnded, cacheForever),
+	"epoch_params": when(epochRequested, cacheForever),
+	"block_info":   when(confirmed(allFound("_block_hashes")), cacheForever),
+	"block_txs":    when(confirmed(hasRecords), cacheForever),
+	"tx_info":      when(confirmed(allFound("_tx_hashes")), cacheForever),
+	// metadata records have no block to check confirmations of
+	"tx_metadata": when(allFound("_tx_hashes"), cacheTTL),
+	"script_info": when(allFound("_script_hashes"), cacheForever),
+	"datum_info":  when(allFound("_datum_hashes"), cacheForever),
+}
+
+// cacheTransport answers requests from on-disk response cache
+// and stores successful responses of the next transport.
+type cacheTransport struct {
+	dir     string
+	mode    string
+	offline bool
+	log     logging.Logger
+	next    http.RoundTripper
+	prune   sync.Once
+}
+
+type cacheEntry struct {
+	URL     string      `json:"url"`
+	Status  int         `json:"status"`
+	Header  http.Header `json:"header"`
+	Body    []byte      `json:"body"`
+	Created time.Time   `json:"created"`
+	// Expires is zero for responses cached forever.
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := path.Base(req.URL.Path)
+	rule, ok := cacheRules[endpoint]
+	if !t.offline && (t.mode == cacheModeOff || !ok) {
+		return t.next.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+	}
+	key := cacheKey(req, body)
+
+	if t.offline || t.mode == cacheModeRead {
+		entry, err := t.load(key)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			t.log.Warn("failed to read cached response", slog.String("endpoint", endpoint), slog.String("err", err.Error()))
+		}
+		if entry != nil && (t.offline || entry.Expires.IsZero() || time.Now().Before(entry.Expires)) {
+			// keep recently used responses on pruning
+			now := time.Now()
+			_ = os.Chtimes(t.file(key), now, now)
+			t.log.Debug("response from cache",
+				slog.String("endpoint", endpoint),
+				slog.Time("created", entry.Created),
+			)
+			return entry.response(req), nil
+		}
+	}
+	if t.offline {
+		return nil, fmt.Errorf("offline: %w: %s", errCacheMiss, endpoint)
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil || (res.StatusCode != http.StatusOK && res.StatusCode != http.StatusPartialContent) {
+		return res, err
+	}
+	rspBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(rspBody))
+
+	var ttl time.Duration
+	if records, err := cacheRecords(res.Header, rspBody); err == nil {
+		ttl = rule(cacheRequest{query: req.URL.Query(), body: body}, records)
+	}
+	if ttl == 0 {
+		return res, nil
+	}
+
+	entry := &cacheEntry{
+		URL:     req.URL.String(),
+		Status:  res.StatusCode,
+		Header:  res.Header.Clone(),
+		Body:    rspBody,
+		Created: time.Now().UTC(),
+	}
+	if ttl != cacheForever {
+		entry.Expires = entry.Created.Add(ttl)
+	}
+	if err := t.store(key, entry); err != nil {
+		t.log.Warn("failed to cache response", slog.String("endpoint", endpoint), slog.String("err", err.Error()))
+	} else {
+		t.log.Debug("response cached", slog.String("endpoint", endpoint), slog.Bool("forever", ttl == cacheForever))
+	}
+	t.prune.Do(func() {
+		if err := pruneCache(t.dir, time.Now().Add(-cacheMaxAge), cacheMaxSize); err != nil {
+			t.log.Warn("failed to prune response cache", slog.String("err", err.Error()))
+		}
+	})
+	return res, nil
+}
+
+// pruneCache removes cached responses last used before oldest and then
+// least recently used responses until size of cache dir is within maxSize.
+func pruneCache(dir string, oldest time.Time, maxSize int64) error {
+	type cached struct {
+		file string
+		size int64
+		used time.Time
+	}
+	var (
+		files []cached
+		size  int64
+	)
+	err := filepath.WalkDir(dir, func(file string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(file, ".json") {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().Before(oldest) {
+			return os.Remove(file)
+		}
+		files = append(files, cached{file: file, size: info.Size(), used: info.ModTime()})
+		size += info.Size()
+		return nil
+	})
+	if err != nil || size <= maxSize {
+		return err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].used.Before(files[j].used)
+	})
+	for _, f := range files {
+		if size <= maxSize {
+			break
+		}
+		if err := os.Remove(f.file); err != nil {
+			return err
+		}
+		size -= f.size
+	}
+	return nil
+}
+
+func (t *cacheTransport) load(key string) (*cacheEntry, error) {
+	data, err := os.ReadFile(t.file(key))
+	if err != nil {
+		return nil, err
+	}
+	entry := &cacheEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (t *cacheTransport) store(key string, entry *cacheEntry) error {
+	file := t.file(key)
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+func (t *cacheTransport) file(key string) string {
+	return filepath.Join(t.dir, key[:2], key+".json")
+}
+
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// cacheKey returns key of the request from host, endpoint, query,
+// requested range and body of the request.
+func cacheKey(req *http.Request, body []byte) string {
+	h := sha256.New()
+	for _, part := range []string{
+		req.Method,
+		req.URL.Host,
+		req.URL.Path,
+		req.URL.Query().Encode(),
+		req.Header.Get("Range"),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheRecords decodes records of the response body.
+func cacheRecords(header http.Header, body []byte) ([]map[string]json.RawMessage, error) {
+	if header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		if body, err = io.ReadAll(zr); err != nil {
+			return nil, err
+		}
+	}
+	var records []map[string]json.RawMessage
+	err := json.Unmarshal(body, &records)
+	return records, err
+}
+
+// cacheCheck reports whether response can be cached.
+type cacheCheck func(req cacheRequest, records []map[string]json.RawMessage) bool
+
+// always caches every response for ttl.
+func always(ttl time.Duration) cacheRule {
+	return func(cacheRequest, []map[string]json.RawMessage) time.Duration {
+		return ttl
+	}
+}
+
+// when caches response for ttl when check passes.
+func when(check cacheCheck, ttl time.Duration) cacheRule {
+	return func(req cacheRequest, records []map[string]json.RawMessage) time.Duration {
+		if check(req, records) {
+			return ttl
+		}
+		return 0
+	}
+}
+
+// confirmed reports whether check passes and all records are in blocks
+// older than cacheConfirmedAge, which can no longer roll back.
+func confirmed(check cacheCheck) cacheCheck {
+	return func(req cacheRequest, records []map[string]json.RawMessage) bool {
+		if !check(req, records) {
+			return false
+		}
+		for _, record := range records {
+			var blockTime int64
+			if err := json.Unmarshal(record["block_time"], &blockTime); err != nil ||
+				time.Since(time.Unix(blockTime, 0)) < cacheConfirmedAge {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func hasRecords(_ cacheRequest, records []map[string]json.RawMessage) bool {
+	return len(records) > 0
+}
+
+// epochRequested reports whether response is for explicitly requested epoch.
+func epochRequested(req cacheRequest, records []map[string]json.RawMessage) bool {
+	return req.query.Has("_epoch_no") && len(records) > 0
+}
+
+// epochEnded reports whether response is for explicitly requested epoch
+// which has already ended.
+func epochEnded(req cacheRequest, records []map[string]json.RawMessage) bool {
+	if !epochRequested(req, records) {
+		return false
+	}
+	for _, record := range records {
+		var end int64
+		if err := json.Unmarshal(record["end_time"], &end); err != nil || time.Unix(end, 0).After(time.Now()) {
+			return false
+		}
+	}
+	return true
+}
+
+// allFound reports whether response has record for each of the
+// identifiers requested with field of the request body.
+func allFound(field string) cacheCheck {
+	return func(req cacheRequest, records []map[string]json.RawMessage) bool {
+		var payload map[string]json.RawMessage
+		if err := json.Unmarshal(req.body, &payload); err != nil {
+			return false
+		}
+		var ids []json.RawMessage
+		if err := json.Unmarshal(payload[field], &ids); err != nil {
+			return false
+		}
+		return len(ids) > 0 && len(records) == len(ids)
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestCacheRules(t *testing.T) {
+	var (
+		old    = time.Now().Add(-2 * cacheConfirmedAge).Unix()
+		recent = time.Now().Add(-time.Minute).Unix()
+		txs    = []byte(`{"_tx_hashes":["a","b"]}`)
+	)
+	tx := func(hash string, blockTime int64) string {
+		return fmt.Sprintf(`{"tx_hash":%q,"block_time":%d}`, hash, blockTime)
+	}
+	tests := []struct {
+		name     string
+		endpoint string
+		query    string
+		body     []byte
+		records  string
+		ttl      time.Duration
+	}{
+		{name: "genesis", endpoint: "genesis", records: `[{"networkmagic":"764824073"}]`, ttl: cacheForever},
+		{name: "confirmed txs", endpoint: "tx_info", body: txs, records: "[" + tx("a", old) + "," + tx("b", old) + "]", ttl: cacheForever},
+		{name: "recent tx", endpoint: "tx_info", body: txs, records: "[" + tx("a", old) + "," + tx("b", recent) + "]"},
+		{name: "tx not found", endpoint: "tx_info", body: txs, records: "[" + tx("a", old) + "]"},
+		{name: "tx without block time", endpoint: "tx_info", body: []byte(`{"_tx_hashes":["a"]}`), records: `[{"tx_hash":"a"}]`},
+		{name: "confirmed block txs", endpoint: "block_txs", records: "[" + tx("a", old) + "]", ttl: cacheForever},
+		{name: "recent block txs", endpoint: "block_txs", records: "[" + tx("a", recent) + "]"},
+		{name: "unknown block txs", endpoint: "block_txs", records: "[]"},
+		{name: "tx metadata", endpoint: "tx_metadata", body: txs, records: `[{"tx_hash":"a"},{"tx_hash":"b"}]`, ttl: cacheTTL},
+		{name: "tx metadata not found", endpoint: "tx_metadata", body: txs, records: `[{"tx_hash":"a"}]`},
+		{name: "ended epoch", endpoint: "epoch_info", query: "_epoch_no=320", records: fmt.Sprintf(`[{"end_time":%d}]`, old), ttl: cacheForever},
+		{name: "current epoch", endpoint: "epoch_info", query: "_epoch_no=320", records: fmt.Sprintf(`[{"end_time":%d}]`, time.Now().Add(time.Hour).Unix())},
+		{name: "latest epoch", endpoint: "epoch_info", records: fmt.Sprintf(`[{"end_time":%d}]`, old)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var records []map[string]json.RawMessage
+			if err := json.Unmarshal([]byte(tt.records), &records); err != nil {
+				t.Fatal(err)
+			}
+			rule, ok := cacheRules[tt.endpoint]
+			if !ok {
+				t.Fatalf("no cache rule for %s", tt.endpoint)
+			}
+			if ttl := rule(cacheRequest{query: query, body: tt.body}, records); ttl != tt.ttl {
+				t.Errorf("cache rule of %s = %s, want %s", tt.endpoint, ttl, tt.ttl)
+			}
+		})
+	}
+
+	for _, endpoint := range []string{"tip", "address_info", "account_info", "tx_status"} {
+		if _, ok := cacheRules[endpoint]; ok {
+			t.Errorf("response of %s is cached", endpoint)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// maxCBORDepth limits nesting of cbor data items.
+const maxCBORDepth = 256
+
+var errCBOR = errors.New("invalid cbor")
+
+// txHash validates that tx is single well-formed cbor array, as signed
+// transactions are, and returns transaction hash, blake2b-256 hash of
+// the transaction body which is first element of the array.
+func txHash(tx []byte) (string, error) {
+	n, err := cborItem(tx, 0)
+	if err != nil {
+		return "", err
+	}
+	if n != len(tx) {
+		return "", fmt.Errorf("%w: %d trailing bytes after transaction", errCBOR, len(tx)-n)
+	}
+	major, _, hn, err := cborHead(tx)
+	if err != nil {
+		return "", err
+	}
+	if major != 4 {
+		return "", fmt.Errorf("%w: transaction must be cbor array, got major type %d", errCBOR, major)
+	}
+	bn, err := cborItem(tx[hn:], 1)
+	if err != nil {
+		return "", err
+	}
+	sum := blake2b.Sum256(tx[hn : hn+bn])
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// cborHead decodes head of cbor data item and returns major type,
+// argument and length of the head. Argument of indefinite length
+// items is -1.
+func cborHead(data []byte) (major byte, arg int64, n int, err error) {
+	if len(data) == 0 {
+		return 0, 0, 0, fmt.Errorf("%w: unexpected end of data", errCBOR)
+	}
+	major, info := data[0]>>5, data[0]&0x1f
+	switch {
+	case info < 24:
+		return major, int64(info), 1, nil
+	case info == 31:
+		if major < 2 || major == 6 {
+			return 0, 0, 0, fmt.Errorf("%w: indefinite length of major type %d", errCBOR, major)
+		}
+		return major, -1, 1, nil
+	case info > 27:
+		return 0, 0, 0, fmt.Errorf("%w: reserved additional information %d", errCBOR, info)
+	}
+	size := 1 << (info - 24)
+	if len(data) < 1+size {
+		return 0, 0, 0, fmt.Errorf("%w: unexpected end of data", errCBOR)
+	}
+	var v uint64
+	switch size {
+	case 1:
+		v = uint64(data[1])
+	case 2:
+		v = uint64(binary.BigEndian.Uint16(data[1:]))
+	case 4:
+		v = uint64(binary.BigEndian.Uint32(data[1:]))
+	case 8:
+		v = binary.BigEndian.Uint64(data[1:])
+	}
+	if v > 1<<62 {
+		return 0, 0, 0, fmt.Errorf("%w: length too large", errCBOR)
+	}
+	return major, int64(v), 1 + size, nil
+}
+
+// cborItem validates well-formedness of cbor data item at the start
+// of data and returns its length.
+func cborItem(data []byte, depth int) (int, error) {
+	if depth > maxCBORDepth {
+		return 0, fmt.Errorf("%w: nesting too deep", errCBOR)
+	}
+	major, arg, n, err := cborHead(data)
+	if err != nil {
+		return 0, err
+	}
+	if arg < 0 {
+		if major == 7 {
+			return 0, fmt.Errorf("%w: unexpected break", errCBOR)
+		}
+		// indefinite length items end with break
+		for {
+			if n >= len(data) {
+				return 0, fmt.Errorf("%w: unexpected end of data", errCBOR)
+			}
+			if data[n] == 0xff {
+				return n + 1, nil
+			}
+			if major == 2 || major == 3 {
+				// chunks of byte and text strings are definite strings of same type
+				if chunk, size, _, err := cborHead(data[n:]); err != nil || chunk != major || size < 0 {
+					return 0, fmt.Errorf("%w: invalid chunk of indefinite length string", errCBOR)
+				}
+			}
+			items := 1
+			if major == 5 {
+				items = 2
+			}
+			for range items {
+				m, err := cborItem(data[n:], depth+1)
+				if err != nil {
+					return 0, err
+				}
+				n += m
+			}
+		}
+	}
+
+	switch major {
+	case 2, 3:
+		if arg > int64(len(data)-n) {
+			return 0, fmt.Errorf("%w: unexpected end of data", errCBOR)
+		}
+		return n + int(arg), nil
+	case 4, 5:
+		items := arg
+		if major == 5 {
+			items *= 2
+		}
+		for range items {
+			m, err := cborItem(data[n:], depth+1)
+			if err != nil {
+				return 0, err
+			}
+			n += m
+		}
+		return n, nil
+	case 6:
+		m, err := cborItem(data[n:], depth+1)
+		if err != nil {
+			return 0, err
+		}
+		return n + m, nil
+	}
+	return n, nil
+}
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testTx is signed shelley transaction [body, witnesses, valid, auxiliary
+// data] spending one mainnet input to enterprise mainnet address.
+const (
+	testTx = "84" +
+		"a4" + // body
+		"00818258203b40265111d8bb3c3c608d95b3a0bf83461ace32d79336579a1939b3aad1c0b700" + // inputs
+		"018182581d6177777777777777777777777777777777777777777777777777777777" + "1a000f4240" + // outputs
+		"021a00029810" + // fee
+		"031a00989680" + // ttl
+		"a0" + // witnesses
+		"f5" + // valid
+		"f6" // auxiliary data
+	testTxHash = "4c41fc1aa39a9ecf767a6efe8d2ab7a931336d955da801d8d858a5b997195ebe"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestCBORItem(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		n    int
+	}{
+		{"uint", "00", 1},
+		{"uint 1 byte", "1818", 2},
+		{"uint 8 bytes", "1b0000000000000001", 9},
+		{"negative int", "20", 1},
+		{"bytes", "43010203", 4},
+		{"text", "6161", 2},
+		{"array", "820102", 3},
+		{"map", "a10102", 3},
+		{"tag", "d8184100", 4},
+		{"float", "f93c00", 3},
+		{"item followed by other", "0001", 1},
+		{"indefinite array", "9f0102ff", 4},
+		{"indefinite map", "bf0102ff", 4},
+		{"indefinite bytes", "5f41004101ff", 6},
+		{"indefinite text", "7f6161ff", 4},
+		{"nested indefinite arrays", "9f9fffff", 4},
+		{"indefinite array of definite arrays", "9f820102a0ff", 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := cborItem(mustHex(t, tt.data), 0)
+			if err != nil {
+				t.Fatalf("cborItem(%s) error: %v", tt.data, err)
+			}
+			if n != tt.n {
+				t.Errorf("cborItem(%s) = %d, want %d", tt.data, n, tt.n)
+			}
+		})
+	}
+}
+
+func TestCBORItemInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"truncated uint argument", "18"},
+		{"truncated length", "1900"},
+		{"truncated bytes", "4301"},
+		{"truncated array", "8201"},
+		{"truncated map", "a101"},
+		{"truncated tag", "d818"},
+		{"indefinite array without break", "9f01"},
+		{"indefinite map with key only", "bf01ff"},
+		{"indefinite uint", "1f"},
+		{"indefinite tag", "df"},
+		{"indefinite bytes with text chunk", "5f6161ff"},
+		{"indefinite text with indefinite chunk", "7f7fffff"},
+		{"reserved additional information", "1c"},
+		{"unexpected break", "ff"},
+		{"length too large", "5bffffffffffffffff"},
+		{"nesting too deep", strings.Repeat("81", maxCBORDepth+1) + "00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := cborItem(mustHex(t, tt.data), 0)
+			if !errors.Is(err, errCBOR) {
+				t.Errorf("cborItem(%s) = %d, %v, want %v", tt.data, n, err, errCBOR)
+			}
+		})
+	}
+}
+
+func TestTxHash(t *testing.T) {
+	tx := testTx
+	tests := []struct {
+		name string
+		tx   string
+		hash string
+		err  string
+	}{
+		{name: "signed tx", tx: tx, hash: testTxHash},
+		{name: "indefinite length tx", tx: "9f" + tx[2:] + "ff", hash: testTxHash},
+		{name: "trailing bytes", tx: tx + "0000", err: "2 trailing bytes"},
+		{name: "truncated", tx: tx[:len(tx)-2], err: "unexpected end of data"},
+		{name: "not array", tx: "a0", err: "must be cbor array"},
+		{name: "empty array", tx: "80", err: "unexpected end of data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := txHash(mustHex(t, tt.tx))
+			if tt.err != "" {
+				if !errors.Is(err, errCBOR) || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("txHash() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("txHash() error: %v", err)
+			}
+			if hash != tt.hash {
+				t.Errorf("txHash() = %s, want %s", hash, tt.hash)
+			}
+		})
+	}
+}
+
+func TestReadTx(t *testing.T) {
+	raw := mustHex(t, testTx)
+	tests := []struct {
+		name  string
+		input []byte
+		err   string
+	}{
+		{name: "raw cbor", input: raw},
+		{name: "hex", input: []byte(testTx)},
+		{name: "hex with newline", input: []byte(testTx + "\n")},
+		{name: "envelope", input: []byte(`{
+  "type": "Witnessed Tx ConwayEra",
+  "description": "Ledger Cddl Format",
+  "cborHex": "` + testTx + `"
+}`)},
+		{name: "unsigned envelope", input: []byte(`{"type": "Unwitnessed Tx ConwayEra", "cborHex": "` + testTx + `"}`), err: "is not signed"},
+		{name: "envelope without cborHex", input: []byte(`{"type": "Witnessed Tx ConwayEra"}`), err: "has no cborHex"},
+		{name: "envelope with invalid cborHex", input: []byte(`{"type": "Witnessed Tx ConwayEra", "cborHex": "8x"}`), err: "failed to decode cborHex"},
+		{name: "empty", input: []byte(" \n"), err: "transaction is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "tx.signed")
+			if err := os.WriteFile(file, tt.input, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			tx, err := readTx(file)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("readTx() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readTx() error: %v", err)
+			}
+			if !bytes.Equal(tx, raw) {
+				t.Errorf("readTx() = %x, want %x", tx, raw)
+			}
+			if hash, err := txHash(tx); err != nil || hash != testTxHash {
+				t.Errorf("txHash(readTx()) = %s, %v, want %s", hash, err, testTxHash)
+			}
+		})
+	}
+}
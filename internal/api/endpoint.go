@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cardano-community/koios-go-client/v4"
+	"github.com/happy-sdk/happy"
+	"github.com/happy-sdk/happy/pkg/options"
+	"github.com/happy-sdk/happy/pkg/vars/varflag"
+)
+
+//go:generate go run ./apigen
+
+// endpoint is definition of api command generated from the vendored
+// Koios OpenAPI specification, see endpoints_gen.go.
+type endpoint struct {
+	name        string
+	method      string
+	category    string
+	description string
+	info        string
+	usage       string
+	argnMin     uint
+	argnMax     uint
+	// batchSize is max number of arguments accepted per request,
+	// more arguments are split into batches, see client.batch.
+	batchSize int
+	// flags of endpoint params, params maps flag names to param names
+	flags  []varflag.FlagCreateFunc
+	params map[string]string
+}
+
+// command returns command of the endpoint without action. Options given
+// override the generated ones, e.g. usage of command reading its input
+// from file instead of arguments.
+func (e endpoint) command(opts ...options.Arg) *happy.Command {
+	cmd := happy.NewCommand(e.name, append([]options.Arg{
+		happy.Option("description", e.description),
+		happy.Option("category", e.category),
+		happy.Option("argn.min", e.argnMin),
+		happy.Option("argn.max", e.argnMax),
+		happy.Option("usage", e.usage),
+	}, opts...)...).WithFlags(e.flags...)
+
+	cmd.AddInfo(e.info)
+	if e.batchSize > 0 {
+		cmd.AddInfo(fmt.Sprintf("More than %d arguments are requested in batches of %d.", e.batchSize, e.batchSize))
+	}
+	cmd.AddInfo("Docs: " + e.docs())
+	return cmd
+}
+
+// docs returns link to documentation of the endpoint.
+func (e endpoint) docs() string {
+	return fmt.Sprintf("https://api.koios.rest/#%s-/%s", strings.ToLower(e.method), e.name)
+}
+
+// query sets query params of endpoint flags present in args.
+func (e endpoint) query(args happy.Args, opts *koios.RequestOptions) {
+	for flag, param := range e.params {
+		if args.Flag(flag).Present() {
+			opts.QuerySet(param, args.Flag(flag).String())
+		}
+	}
+}
+
+// payload returns request body with batch of arguments as array param
+// and params of endpoint flags present in args.
+func (e endpoint) payload(args happy.Args, param string, batch any) map[string]any {
+	payload := map[string]any{param: batch}
+	for flag, param := range e.params {
+		if args.Flag(flag).Present() {
+			payload[param] = args.Flag(flag).Var().Any()
+		}
+	}
+	return payload
+}
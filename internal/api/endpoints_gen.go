@@ -0,0 +1,1183 @@
+// Code generated by apigen from koiosapi.yaml; DO NOT EDIT.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/happy-sdk/happy/pkg/vars/varflag"
+)
+
+// endpointTip is definition of GET /tip command.
+var endpointTip = endpoint{
+	name:        "tip",
+	method:      http.MethodGet,
+	category:    categoryNetwork,
+	description: "Query Chain Tip",
+	info:        "Get the tip info about the latest block seen by chain",
+	usage:       "koios api tip",
+}
+
+// endpointGenesis is definition of GET /genesis command.
+var endpointGenesis = endpoint{
+	name:        "genesis",
+	method:      http.MethodGet,
+	category:    categoryNetwork,
+	description: "Get Genesis info",
+	info:        "Get the Genesis parameters used to start specific era on chain",
+	usage:       "koios api genesis",
+}
+
+// endpointTotals is definition of GET /totals command.
+var endpointTotals = endpoint{
+	name:        "totals",
+	method:      http.MethodGet,
+	category:    categoryNetwork,
+	description: "Get historical tokenomic stats",
+	info:        "Get the circulating utxo, treasury, rewards, supply and reserves in lovelace for specified epoch, all epochs if empty",
+	usage:       "koios api totals",
+	flags: []varflag.FlagCreateFunc{
+		epochNoFlag,
+	},
+	params: map[string]string{
+		"epoch": "_epoch_no",
+	},
+}
+
+// endpointParamUpdates is definition of GET /param_updates command.
+var endpointParamUpdates = endpoint{
+	name:        "param_updates",
+	method:      http.MethodGet,
+	category:    categoryNetwork,
+	description: "Param Update Proposals",
+	info:        "Get all parameter update proposals submitted to the chain starting Shelley era",
+	usage:       "koios api param_updates",
+}
+
+// endpointCliProtocolParams is definition of GET /cli_protocol_params command.
+var endpointCliProtocolParams = endpoint{
+	name:        "cli_protocol_params",
+	method:      http.MethodGet,
+	category:    categoryNetwork,
+	description: "CLI Protocol Parameters",
+	info:        "Get Current Protocol Parameters as published by cardano-cli. Note that the output schema of this command is unfortunately fluid on cardano-node and may vary between CLI versions/era. Accordingly, the returned output for this endpoint is left as raw JSON",
+	usage:       "koios api cli_protocol_params",
+}
+
+// endpointReserveWithdrawals is definition of GET /reserve_withdrawals command.
+var endpointReserveWithdrawals = endpoint{
+	name:        "reserve_withdrawals",
+	method:      http.MethodGet,
+	category:    categoryNetwork,
+	description: "Reserve Withdrawals",
+	info:        "List of all withdrawals from reserves against stake accounts",
+	usage:       "koios api reserve_withdrawals",
+}
+
+// endpointTreasuryWithdrawals is definition of GET /treasury_withdrawals command.
+var endpointTreasuryWithdrawals = endpoint{
+	name:        "treasury_withdrawals",
+	method:      http.MethodGet,
+	category:    categoryNetwork,
+	description: "Treasury Withdrawals",
+	info:        "List of all withdrawals from treasury against stake accounts",
+	usage:       "koios api treasury_withdrawals",
+}
+
+// endpointEpochInfo is definition of GET /epoch_info command.
+var endpointEpochInfo = endpoint{
+	name:        "epoch_info",
+	method:      http.MethodGet,
+	category:    categoryEpoch,
+	description: "Epoch Information",
+	info:        "Get the epoch information, all epochs if no epoch specified",
+	usage:       "koios api epoch_info [_epoch_no]",
+	argnMax:     1,
+	flags: []varflag.FlagCreateFunc{
+		varflag.BoolFunc("include-next-epoch", false, "Include information about nearing but not yet started epoch, to get access to active stake snapshot information if available"),
+	},
+	params: map[string]string{
+		"include-next-epoch": "_include_next_epoch",
+	},
+}
+
+// endpointEpochParams is definition of GET /epoch_params command.
+var endpointEpochParams = endpoint{
+	name:        "epoch_params",
+	method:      http.MethodGet,
+	category:    categoryEpoch,
+	description: "Epoch's Protocol Parameters",
+	info:        "Get the protocol parameters for specific epoch, returns information about all epochs if no epoch specified",
+	usage:       "koios api epoch_params [_epoch_no]",
+	argnMax:     1,
+}
+
+// endpointEpochBlockProtocols is definition of GET /epoch_block_protocols command.
+var endpointEpochBlockProtocols = endpoint{
+	name:        "epoch_block_protocols",
+	method:      http.MethodGet,
+	category:    categoryEpoch,
+	description: "Epoch's Block Protocols",
+	info:        "Get the information about block protocol distribution in epoch",
+	usage:       "koios api epoch_block_protocols [_epoch_no]",
+	argnMax:     1,
+}
+
+// endpointBlocks is definition of GET /blocks command.
+var endpointBlocks = endpoint{
+	name:        "blocks",
+	method:      http.MethodGet,
+	category:    categoryBlock,
+	description: "Block List",
+	info:        "Get summarised details about all blocks (paginated - latest first)",
+	usage:       "koios api blocks",
+}
+
+// endpointBlockInfo is definition of POST /block_info command.
+var endpointBlockInfo = endpoint{
+	name:        "block_info",
+	method:      http.MethodPost,
+	category:    categoryBlock,
+	description: "Block Information",
+	info:        "Get detailed information about a specific block",
+	usage:       "koios api block_info [_block_hashes...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointBlockTxs is definition of POST /block_txs command.
+var endpointBlockTxs = endpoint{
+	name:        "block_txs",
+	method:      http.MethodPost,
+	category:    categoryBlock,
+	description: "Block Transactions",
+	info:        "Get a list of all transactions included in provided blocks",
+	usage:       "koios api block_txs [_block_hashes...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointUtxoInfo is definition of POST /utxo_info command.
+var endpointUtxoInfo = endpoint{
+	name:        "utxo_info",
+	method:      http.MethodPost,
+	category:    categoryTransactions,
+	description: "UTxO Info",
+	info:        "Get UTxO set for requested UTxO references",
+	usage:       "koios api utxo_info [_utxo_refs...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		extendedFlag,
+	},
+	params: map[string]string{
+		"extended": "_extended",
+	},
+}
+
+// endpointTxCbor is definition of POST /tx_cbor command.
+var endpointTxCbor = endpoint{
+	name:        "tx_cbor",
+	method:      http.MethodPost,
+	category:    categoryTransactions,
+	description: "Raw Transaction (CBOR)",
+	info:        "Get raw transaction(s) in CBOR format",
+	usage:       "koios api tx_cbor [_tx_hashes...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointTxInfo is definition of POST /tx_info command.
+var endpointTxInfo = endpoint{
+	name:        "tx_info",
+	method:      http.MethodPost,
+	category:    categoryTransactions,
+	description: "Transaction Information",
+	info:        "Get detailed information about transaction(s)",
+	usage:       "koios api tx_info [_tx_hashes...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		varflag.BoolFunc("inputs", false, "Controls whether to include transaction inputs in the result"),
+		varflag.BoolFunc("metadata", false, "Controls whether to include transaction metadata in the result"),
+		varflag.BoolFunc("assets", false, "Controls whether to include assets involved within transaction the result"),
+		varflag.BoolFunc("withdrawals", false, "Controls whether to include any stake account reward withdrawals in the result"),
+		varflag.BoolFunc("certs", false, "Controls whether to include transaction certificates in the result"),
+		varflag.BoolFunc("scripts", false, "Controls whether to include any details regarding collateral/reference/datum/script objects in the result"),
+		varflag.BoolFunc("bytecode", false, "Controls whether to include bytecode for associated reference/plutus scripts"),
+		varflag.BoolFunc("governance", false, "Controls whether to include governance certificates, votes and proposals in the result"),
+	},
+	params: map[string]string{
+		"inputs":      "_inputs",
+		"metadata":    "_metadata",
+		"assets":      "_assets",
+		"withdrawals": "_withdrawals",
+		"certs":       "_certs",
+		"scripts":     "_scripts",
+		"bytecode":    "_bytecode",
+		"governance":  "_governance",
+	},
+}
+
+// endpointTxMetadata is definition of POST /tx_metadata command.
+var endpointTxMetadata = endpoint{
+	name:        "tx_metadata",
+	method:      http.MethodPost,
+	category:    categoryTransactions,
+	description: "Transaction Metadata",
+	info:        "Get metadata information (if any) for given transaction(s)",
+	usage:       "koios api tx_metadata [_tx_hashes...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointTxMetalabels is definition of GET /tx_metalabels command.
+var endpointTxMetalabels = endpoint{
+	name:        "tx_metalabels",
+	method:      http.MethodGet,
+	category:    categoryTransactions,
+	description: "Transaction Metadata Labels",
+	info:        "Get a list of all transaction metalabels",
+	usage:       "koios api tx_metalabels",
+}
+
+// endpointSubmittx is definition of POST /submittx command.
+var endpointSubmittx = endpoint{
+	name:        "submittx",
+	method:      http.MethodPost,
+	category:    categoryTransactions,
+	description: "Submit Transaction",
+	info:        "Submit an already serialized transaction to the network.",
+	usage:       "koios api submittx",
+}
+
+// endpointTxStatus is definition of POST /tx_status command.
+var endpointTxStatus = endpoint{
+	name:        "tx_status",
+	method:      http.MethodPost,
+	category:    categoryTransactions,
+	description: "Transaction Status",
+	info:        "Get the number of block confirmations for a given transaction hash list",
+	usage:       "koios api tx_status [_tx_hashes...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointTxUtxos is definition of POST /tx_utxos command.
+var endpointTxUtxos = endpoint{
+	name:        "tx_utxos",
+	method:      http.MethodPost,
+	category:    categoryTransactions,
+	description: "Transaction UTxOs",
+	info:        "Get UTxO set (inputs/outputs) of transactions [DEPRECATED - Use /utxo_info or /tx_info instead].",
+	usage:       "koios api tx_utxos [_tx_hashes...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointAccountList is definition of GET /account_list command.
+var endpointAccountList = endpoint{
+	name:        "account_list",
+	method:      http.MethodGet,
+	category:    categoryStakeAccount,
+	description: "Account List",
+	info:        "Get a list of all stake addresses that have atleast 1 transaction",
+	usage:       "koios api account_list",
+}
+
+// endpointAccountInfo is definition of POST /account_info command.
+var endpointAccountInfo = endpoint{
+	name:        "account_info",
+	method:      http.MethodPost,
+	category:    categoryStakeAccount,
+	description: "Account Information",
+	info:        "Get the account information for given stake addresses",
+	usage:       "koios api account_info [_stake_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointAccountInfoCached is definition of POST /account_info_cached command.
+var endpointAccountInfoCached = endpoint{
+	name:        "account_info_cached",
+	method:      http.MethodPost,
+	category:    categoryStakeAccount,
+	description: "Account Information (Cached)",
+	info:        "Get the cached account information for given stake addresses (effective for performance query against registered accounts)",
+	usage:       "koios api account_info_cached [_stake_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointAccountUtxos is definition of POST /account_utxos command.
+var endpointAccountUtxos = endpoint{
+	name:        "account_utxos",
+	method:      http.MethodPost,
+	category:    categoryStakeAccount,
+	description: "Account UTxOs",
+	info:        "Get a list of all UTxOs for given stake addresses (account)s",
+	usage:       "koios api account_utxos [_stake_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		extendedFlag,
+	},
+	params: map[string]string{
+		"extended": "_extended",
+	},
+}
+
+// endpointAccountTxs is definition of GET /account_txs command.
+var endpointAccountTxs = endpoint{
+	name:        "account_txs",
+	method:      http.MethodGet,
+	category:    categoryStakeAccount,
+	description: "Account Txs",
+	info:        "Get a list of all Txs for a given stake address (account)",
+	usage:       "koios api account_txs [_stake_address]",
+	argnMin:     1,
+	argnMax:     1,
+	flags: []varflag.FlagCreateFunc{
+		varflag.UintFunc("after-block-height", 0, "Block height for specifying time delta"),
+	},
+	params: map[string]string{
+		"after-block-height": "_after_block_height",
+	},
+}
+
+// endpointAccountRewards is definition of POST /account_rewards command.
+var endpointAccountRewards = endpoint{
+	name:        "account_rewards",
+	method:      http.MethodPost,
+	category:    categoryStakeAccount,
+	description: "Account Rewards",
+	info:        "Get the full rewards history (including MIR) for given stake addresses",
+	usage:       "koios api account_rewards [_stake_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		epochNoFlag,
+	},
+	params: map[string]string{
+		"epoch": "_epoch_no",
+	},
+}
+
+// endpointAccountUpdates is definition of POST /account_updates command.
+var endpointAccountUpdates = endpoint{
+	name:        "account_updates",
+	method:      http.MethodPost,
+	category:    categoryStakeAccount,
+	description: "Account Updates",
+	info:        "Get the account updates (registration, deregistration, delegation and withdrawals) for given stake addresses",
+	usage:       "koios api account_updates [_stake_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointAccountAddresses is definition of POST /account_addresses command.
+var endpointAccountAddresses = endpoint{
+	name:        "account_addresses",
+	method:      http.MethodPost,
+	category:    categoryStakeAccount,
+	description: "Account Addresses",
+	info:        "Get all addresses associated with given staking accounts",
+	usage:       "koios api account_addresses [_stake_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		varflag.BoolFunc("first-only", false, "Only return the first result"),
+		varflag.BoolFunc("empty", false, "Include zero quantity entries"),
+	},
+	params: map[string]string{
+		"first-only": "_first_only",
+		"empty":      "_empty",
+	},
+}
+
+// endpointAccountAssets is definition of POST /account_assets command.
+var endpointAccountAssets = endpoint{
+	name:        "account_assets",
+	method:      http.MethodPost,
+	category:    categoryStakeAccount,
+	description: "Account Assets",
+	info:        "Get the native asset balance for a given stake address",
+	usage:       "koios api account_assets [_stake_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointAccountHistory is definition of POST /account_history command.
+var endpointAccountHistory = endpoint{
+	name:        "account_history",
+	method:      http.MethodPost,
+	category:    categoryStakeAccount,
+	description: "Account History",
+	info:        "Get the staking history of given stake addresses (accounts)",
+	usage:       "koios api account_history [_stake_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		epochNoFlag,
+	},
+	params: map[string]string{
+		"epoch": "_epoch_no",
+	},
+}
+
+// endpointAccountStakeHistory is definition of POST /account_stake_history command.
+var endpointAccountStakeHistory = endpoint{
+	name:        "account_stake_history",
+	method:      http.MethodPost,
+	category:    categoryStakeAccount,
+	description: "Account Stake History",
+	info:        "Get the active stake history of given stake addresses (accounts)",
+	usage:       "koios api account_stake_history [_stake_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		epochNoFlag,
+	},
+	params: map[string]string{
+		"epoch": "_epoch_no",
+	},
+}
+
+// endpointAddressInfo is definition of POST /address_info command.
+var endpointAddressInfo = endpoint{
+	name:        "address_info",
+	method:      http.MethodPost,
+	category:    categoryAddress,
+	description: "Address Information",
+	info:        "Get address info - balance, associated stake address (if any) and UTxO set for given addresses",
+	usage:       "koios api address_info [_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   100,
+}
+
+// endpointAddressUtxos is definition of POST /address_utxos command.
+var endpointAddressUtxos = endpoint{
+	name:        "address_utxos",
+	method:      http.MethodPost,
+	category:    categoryAddress,
+	description: "Address UTXOs",
+	info:        "Get UTxO set for given addresses",
+	usage:       "koios api address_utxos [_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		extendedFlag,
+	},
+	params: map[string]string{
+		"extended": "_extended",
+	},
+}
+
+// endpointCredentialUtxos is definition of POST /credential_utxos command.
+var endpointCredentialUtxos = endpoint{
+	name:        "credential_utxos",
+	method:      http.MethodPost,
+	category:    categoryAddress,
+	description: "UTxOs from payment credentials",
+	info:        "Get a list of UTxO against input payment credential array including their balances",
+	usage:       "koios api credential_utxos [_payment_credentials...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		extendedFlag,
+	},
+	params: map[string]string{
+		"extended": "_extended",
+	},
+}
+
+// endpointAddressTxs is definition of POST /address_txs command.
+var endpointAddressTxs = endpoint{
+	name:        "address_txs",
+	method:      http.MethodPost,
+	category:    categoryAddress,
+	description: "Address Transactions",
+	info:        "Get the transaction hash list of input address array, optionally filtering after specified block height (inclusive)",
+	usage:       "koios api address_txs [_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		varflag.UintFunc("after-block-height", 0, "Only fetch information after specific block height"),
+	},
+	params: map[string]string{
+		"after-block-height": "_after_block_height",
+	},
+}
+
+// endpointAddressOutputs is definition of POST /address_outputs command.
+var endpointAddressOutputs = endpoint{
+	name:        "address_outputs",
+	method:      http.MethodPost,
+	category:    categoryAddress,
+	description: "Address Outputs",
+	info:        "Get a list of all outputs (spent or unspent) of input address array, optionally filtering after specified block height (inclusive)",
+	usage:       "koios api address_outputs [_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		varflag.UintFunc("after-block-height", 0, "Only fetch information after specific block height"),
+	},
+	params: map[string]string{
+		"after-block-height": "_after_block_height",
+	},
+}
+
+// endpointCredentialTxs is definition of POST /credential_txs command.
+var endpointCredentialTxs = endpoint{
+	name:        "credential_txs",
+	method:      http.MethodPost,
+	category:    categoryAddress,
+	description: "Transactions from payment credentials",
+	info:        "Get the transaction hash list of input payment credential array, optionally filtering after specified block height (inclusive)",
+	usage:       "koios api credential_txs [_payment_credentials...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		varflag.UintFunc("after-block-height", 0, "Only fetch information after specific block height"),
+	},
+	params: map[string]string{
+		"after-block-height": "_after_block_height",
+	},
+}
+
+// endpointAddressAssets is definition of POST /address_assets command.
+var endpointAddressAssets = endpoint{
+	name:        "address_assets",
+	method:      http.MethodPost,
+	category:    categoryAddress,
+	description: "Address Assets",
+	info:        "Get the list of all the assets (policy, name and quantity) for given addresses",
+	usage:       "koios api address_assets [_addresses...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointAssetList is definition of GET /asset_list command.
+var endpointAssetList = endpoint{
+	name:        "asset_list",
+	method:      http.MethodGet,
+	category:    categoryAsset,
+	description: "Asset List",
+	info:        "Get the list of all native assets (paginated)",
+	usage:       "koios api asset_list",
+}
+
+// endpointPolicyAssetList is definition of GET /policy_asset_list command.
+var endpointPolicyAssetList = endpoint{
+	name:        "policy_asset_list",
+	method:      http.MethodGet,
+	category:    categoryAsset,
+	description: "Policy Asset List",
+	info:        "Get the list of asset under the given policy (including balances)",
+	usage:       "koios api policy_asset_list [_asset_policy]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointAssetTokenRegistry is definition of GET /asset_token_registry command.
+var endpointAssetTokenRegistry = endpoint{
+	name:        "asset_token_registry",
+	method:      http.MethodGet,
+	category:    categoryAsset,
+	description: "Asset Token Registry",
+	info:        "Get a list of assets registered via token registry on github",
+	usage:       "koios api asset_token_registry",
+}
+
+// endpointAssetInfo is definition of POST /asset_info command.
+var endpointAssetInfo = endpoint{
+	name:        "asset_info",
+	method:      http.MethodPost,
+	category:    categoryAsset,
+	description: "Asset Information (Bulk)",
+	info:        "Get the information of a list of assets including first minting & token registry metadata",
+	usage:       "koios api asset_info [_asset_list...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointAssetUtxos is definition of POST /asset_utxos command.
+var endpointAssetUtxos = endpoint{
+	name:        "asset_utxos",
+	method:      http.MethodPost,
+	category:    categoryAsset,
+	description: "Asset UTXOs",
+	info:        "Get the UTXO information of a list of assets",
+	usage:       "koios api asset_utxos [_asset_list...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+	flags: []varflag.FlagCreateFunc{
+		extendedFlag,
+	},
+	params: map[string]string{
+		"extended": "_extended",
+	},
+}
+
+// endpointAssetHistory is definition of GET /asset_history command.
+var endpointAssetHistory = endpoint{
+	name:        "asset_history",
+	method:      http.MethodGet,
+	category:    categoryAsset,
+	description: "Asset History",
+	info:        "Get the mint/burn history of an asset",
+	usage:       "koios api asset_history [_asset_policy] [_asset_name]",
+	argnMin:     1,
+	argnMax:     2,
+}
+
+// endpointAssetAddresses is definition of GET /asset_addresses command.
+var endpointAssetAddresses = endpoint{
+	name:        "asset_addresses",
+	method:      http.MethodGet,
+	category:    categoryAsset,
+	description: "Asset Addresses",
+	info:        "Get the list of all addresses holding a given asset",
+	usage:       "koios api asset_addresses [_asset_policy] [_asset_name]",
+	argnMin:     1,
+	argnMax:     2,
+}
+
+// endpointAssetNftAddress is definition of GET /asset_nft_address command.
+var endpointAssetNftAddress = endpoint{
+	name:        "asset_nft_address",
+	method:      http.MethodGet,
+	category:    categoryAsset,
+	description: "NFT Address",
+	info:        "Get the address where specified NFT currently reside on",
+	usage:       "koios api asset_nft_address [_asset_policy] [_asset_name]",
+	argnMin:     1,
+	argnMax:     2,
+}
+
+// endpointPolicyAssetAddresses is definition of GET /policy_asset_addresses command.
+var endpointPolicyAssetAddresses = endpoint{
+	name:        "policy_asset_addresses",
+	method:      http.MethodGet,
+	category:    categoryAsset,
+	description: "Policy Asset Address List",
+	info:        "Get the list of addresses with quantity for each asset on the given policy",
+	usage:       "koios api policy_asset_addresses [_asset_policy]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointPolicyAssetInfo is definition of GET /policy_asset_info command.
+var endpointPolicyAssetInfo = endpoint{
+	name:        "policy_asset_info",
+	method:      http.MethodGet,
+	category:    categoryAsset,
+	description: "Policy Asset Information",
+	info:        "Get the information for all assets under the same policy",
+	usage:       "koios api policy_asset_info [_asset_policy]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointPolicyAssetMints is definition of GET /policy_asset_mints command.
+var endpointPolicyAssetMints = endpoint{
+	name:        "policy_asset_mints",
+	method:      http.MethodGet,
+	category:    categoryAsset,
+	description: "Policy Asset Mints",
+	info:        "Get a list of mint or burn count details for all assets minted under a policy",
+	usage:       "koios api policy_asset_mints [_asset_policy]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointAssetSummary is definition of GET /asset_summary command.
+var endpointAssetSummary = endpoint{
+	name:        "asset_summary",
+	method:      http.MethodGet,
+	category:    categoryAsset,
+	description: "Asset Summary",
+	info:        "Get the summary of an asset (total transactions exclude minting/total wallets include only wallets with asset balance)",
+	usage:       "koios api asset_summary [_asset_policy] [_asset_name]",
+	argnMin:     1,
+	argnMax:     2,
+}
+
+// endpointAssetTxs is definition of GET /asset_txs command.
+var endpointAssetTxs = endpoint{
+	name:        "asset_txs",
+	method:      http.MethodGet,
+	category:    categoryAsset,
+	description: "Asset Transactions",
+	info:        "Get the list of current or all asset transaction hashes (newest first)",
+	usage:       "koios api asset_txs [_asset_policy] [_asset_name]",
+	argnMin:     1,
+	argnMax:     2,
+	flags: []varflag.FlagCreateFunc{
+		varflag.UintFunc("after-block-height", 0, "Block height for specifying time delta"),
+		varflag.BoolFunc("history", false, "Include all historical transactions, setting to false includes only the non-empty ones"),
+	},
+	params: map[string]string{
+		"after-block-height": "_after_block_height",
+		"history":            "_history",
+	},
+}
+
+// endpointDrepEpochSummary is definition of GET /drep_epoch_summary command.
+var endpointDrepEpochSummary = endpoint{
+	name:        "drep_epoch_summary",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "DReps Epoch Summary",
+	info:        "Summary of voting power and DRep count for each epoch",
+	usage:       "koios api drep_epoch_summary",
+	flags: []varflag.FlagCreateFunc{
+		epochNoFlag,
+	},
+	params: map[string]string{
+		"epoch": "_epoch_no",
+	},
+}
+
+// endpointDrepList is definition of GET /drep_list command.
+var endpointDrepList = endpoint{
+	name:        "drep_list",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "DReps List",
+	info:        "List of all active delegated representatives (DReps)",
+	usage:       "koios api drep_list",
+}
+
+// endpointDrepInfo is definition of POST /drep_info command.
+var endpointDrepInfo = endpoint{
+	name:        "drep_info",
+	method:      http.MethodPost,
+	category:    categoryGovernance,
+	description: "DReps Info",
+	info:        "Get detailed information about requested delegated representatives (DReps)",
+	usage:       "koios api drep_info [_drep_ids...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointDrepMetadata is definition of POST /drep_metadata command.
+var endpointDrepMetadata = endpoint{
+	name:        "drep_metadata",
+	method:      http.MethodPost,
+	category:    categoryGovernance,
+	description: "DReps Metadata",
+	info:        "List metadata for requested delegated representatives (DReps)",
+	usage:       "koios api drep_metadata [_drep_ids...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointDrepUpdates is definition of GET /drep_updates command.
+var endpointDrepUpdates = endpoint{
+	name:        "drep_updates",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "DReps Updates",
+	info:        "List of updates for requested (or all) delegated representatives (DReps)",
+	usage:       "koios api drep_updates [_drep_id]",
+	argnMax:     1,
+}
+
+// endpointDrepVotingPowerHistory is definition of GET /drep_voting_power_history command.
+var endpointDrepVotingPowerHistory = endpoint{
+	name:        "drep_voting_power_history",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "DReps Voting Power History",
+	info:        "History of DReps voting power against each (or requested) epoch",
+	usage:       "koios api drep_voting_power_history [_drep_id]",
+	argnMax:     1,
+	flags: []varflag.FlagCreateFunc{
+		epochNoFlag,
+	},
+	params: map[string]string{
+		"epoch": "_epoch_no",
+	},
+}
+
+// endpointDrepDelegators is definition of GET /drep_delegators command.
+var endpointDrepDelegators = endpoint{
+	name:        "drep_delegators",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "DReps Delegators List",
+	info:        "List of all delegators to requested delegated representative (DRep)",
+	usage:       "koios api drep_delegators [_drep_id]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointDrepVotes is definition of GET /drep_votes command.
+var endpointDrepVotes = endpoint{
+	name:        "drep_votes",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "DReps Votes",
+	info:        "List of all votes casted by requested delegated representative (DRep)",
+	usage:       "koios api drep_votes [_drep_id]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointProposalList is definition of GET /proposal_list command.
+var endpointProposalList = endpoint{
+	name:        "proposal_list",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "Proposals List",
+	info:        "List of all governance proposals",
+	usage:       "koios api proposal_list",
+}
+
+// endpointVoterProposalList is definition of GET /voter_proposal_list command.
+var endpointVoterProposalList = endpoint{
+	name:        "voter_proposal_list",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "Voter's Proposal List",
+	info:        "List of all governance proposals for specified DRep, SPO or Committee credential",
+	usage:       "koios api voter_proposal_list [_voter_id]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointProposalVotingSummary is definition of GET /proposal_voting_summary command.
+var endpointProposalVotingSummary = endpoint{
+	name:        "proposal_voting_summary",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "Proposal Voting Summary",
+	info:        "Summary of votes for given proposal",
+	usage:       "koios api proposal_voting_summary [_proposal_id]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointProposalVotes is definition of GET /proposal_votes command.
+var endpointProposalVotes = endpoint{
+	name:        "proposal_votes",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "Proposal Votes",
+	info:        "List of all votes cast on specified governance action",
+	usage:       "koios api proposal_votes [_proposal_id]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointPoolVotes is definition of GET /pool_votes command.
+var endpointPoolVotes = endpoint{
+	name:        "pool_votes",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "Pool Votes",
+	info:        "List of all votes casted by a pool",
+	usage:       "koios api pool_votes [_pool_bech32]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointPoolVotingPowerHistory is definition of GET /pool_voting_power_history command.
+var endpointPoolVotingPowerHistory = endpoint{
+	name:        "pool_voting_power_history",
+	method:      http.MethodGet,
+	category:    categoryPool,
+	description: "Pool Voting Power History",
+	info:        "History of Pools voting power against each (or requested) epoch",
+	usage:       "koios api pool_voting_power_history [_pool_bech32]",
+	argnMax:     1,
+	flags: []varflag.FlagCreateFunc{
+		epochNoFlag,
+	},
+	params: map[string]string{
+		"epoch": "_epoch_no",
+	},
+}
+
+// endpointCommitteeInfo is definition of GET /committee_info command.
+var endpointCommitteeInfo = endpoint{
+	name:        "committee_info",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "Committee Information",
+	info:        "Information about active committee and its members",
+	usage:       "koios api committee_info",
+}
+
+// endpointCommitteeVotes is definition of GET /committee_votes command.
+var endpointCommitteeVotes = endpoint{
+	name:        "committee_votes",
+	method:      http.MethodGet,
+	category:    categoryGovernance,
+	description: "Committee Votes",
+	info:        "List of all votes casted by given committee member or collective",
+	usage:       "koios api committee_votes [_cc_hot_id]",
+	argnMax:     1,
+}
+
+// endpointPoolList is definition of GET /pool_list command.
+var endpointPoolList = endpoint{
+	name:        "pool_list",
+	method:      http.MethodGet,
+	category:    categoryPool,
+	description: "Pool List",
+	info:        "List of brief info for all pools",
+	usage:       "koios api pool_list",
+}
+
+// endpointPoolInfo is definition of POST /pool_info command.
+var endpointPoolInfo = endpoint{
+	name:        "pool_info",
+	method:      http.MethodPost,
+	category:    categoryPool,
+	description: "Pool Information",
+	info:        "Current pool statuses and details for a specified list of pool ids",
+	usage:       "koios api pool_info [_pool_bech32_ids...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointPoolStakeSnapshot is definition of GET /pool_stake_snapshot command.
+var endpointPoolStakeSnapshot = endpoint{
+	name:        "pool_stake_snapshot",
+	method:      http.MethodGet,
+	category:    categoryPool,
+	description: "Pool Stake Snapshot",
+	info:        "Returns Mark, Set and Go stake snapshots for the selected pool, useful for leaderlog calculation",
+	usage:       "koios api pool_stake_snapshot [_pool_bech32]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointPoolDelegators is definition of GET /pool_delegators command.
+var endpointPoolDelegators = endpoint{
+	name:        "pool_delegators",
+	method:      http.MethodGet,
+	category:    categoryPool,
+	description: "Pool Delegators List",
+	info:        "Return information about live delegators for a given pool.",
+	usage:       "koios api pool_delegators [_pool_bech32]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointPoolDelegatorsHistory is definition of GET /pool_delegators_history command.
+var endpointPoolDelegatorsHistory = endpoint{
+	name:        "pool_delegators_history",
+	method:      http.MethodGet,
+	category:    categoryPool,
+	description: "Pool Delegators History",
+	info:        "Return information about active delegators (incl. history) for a given pool and epoch number (all epochs if not specified).",
+	usage:       "koios api pool_delegators_history [_pool_bech32]",
+	argnMin:     1,
+	argnMax:     1,
+	flags: []varflag.FlagCreateFunc{
+		epochNoFlag,
+	},
+	params: map[string]string{
+		"epoch": "_epoch_no",
+	},
+}
+
+// endpointPoolBlocks is definition of GET /pool_blocks command.
+var endpointPoolBlocks = endpoint{
+	name:        "pool_blocks",
+	method:      http.MethodGet,
+	category:    categoryPool,
+	description: "Pool Blocks",
+	info:        "Return information about blocks minted by a given pool for all epochs (or _epoch_no if provided)",
+	usage:       "koios api pool_blocks [_pool_bech32]",
+	argnMin:     1,
+	argnMax:     1,
+	flags: []varflag.FlagCreateFunc{
+		epochNoFlag,
+	},
+	params: map[string]string{
+		"epoch": "_epoch_no",
+	},
+}
+
+// endpointPoolOwnerHistory is definition of POST /pool_owner_history command.
+var endpointPoolOwnerHistory = endpoint{
+	name:        "pool_owner_history",
+	method:      http.MethodPost,
+	category:    categoryPool,
+	description: "Pool Owner History",
+	info:        "Return information about owner history of specified pools",
+	usage:       "koios api pool_owner_history [_pool_bech32_ids...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointPoolHistory is definition of GET /pool_history command.
+var endpointPoolHistory = endpoint{
+	name:        "pool_history",
+	method:      http.MethodGet,
+	category:    categoryPool,
+	description: "Pool Stake, Block and Reward History",
+	info:        "Return information about pool stake, block and reward history in a given epoch _epoch_no (or all epochs that pool existed for, in descending order if no _epoch_no was provided)",
+	usage:       "koios api pool_history [_pool_bech32]",
+	argnMin:     1,
+	argnMax:     1,
+	flags: []varflag.FlagCreateFunc{
+		epochNoFlag,
+	},
+	params: map[string]string{
+		"epoch": "_epoch_no",
+	},
+}
+
+// endpointPoolUpdates is definition of GET /pool_updates command.
+var endpointPoolUpdates = endpoint{
+	name:        "pool_updates",
+	method:      http.MethodGet,
+	category:    categoryPool,
+	description: "Pool Updates (History)",
+	info:        "Return all pool updates for all pools or only updates for specific pool if specified",
+	usage:       "koios api pool_updates [_pool_bech32]",
+	argnMax:     1,
+}
+
+// endpointPoolRegistrations is definition of GET /pool_registrations command.
+var endpointPoolRegistrations = endpoint{
+	name:        "pool_registrations",
+	method:      http.MethodGet,
+	category:    categoryPool,
+	description: "Pool Registrations",
+	info:        "Return all pool registrations initiated in the requested epoch",
+	usage:       "koios api pool_registrations [_epoch_no]",
+	argnMax:     1,
+}
+
+// endpointPoolRetirements is definition of GET /pool_retirements command.
+var endpointPoolRetirements = endpoint{
+	name:        "pool_retirements",
+	method:      http.MethodGet,
+	category:    categoryPool,
+	description: "Pool Retirements",
+	info:        "Return all pool retirements initiated in the requested epoch",
+	usage:       "koios api pool_retirements [_epoch_no]",
+	argnMax:     1,
+}
+
+// endpointPoolRelays is definition of GET /pool_relays command.
+var endpointPoolRelays = endpoint{
+	name:        "pool_relays",
+	method:      http.MethodGet,
+	category:    categoryPool,
+	description: "Pool Relays",
+	info:        "A list of registered relays for all pools",
+	usage:       "koios api pool_relays",
+}
+
+// endpointPoolMetadata is definition of POST /pool_metadata command.
+var endpointPoolMetadata = endpoint{
+	name:        "pool_metadata",
+	method:      http.MethodPost,
+	category:    categoryPool,
+	description: "Pool Metadata",
+	info:        "Metadata (on & off-chain) for all pools",
+	usage:       "koios api pool_metadata [_pool_bech32_ids...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointPoolCalidusKeys is definition of GET /pool_calidus_keys command.
+var endpointPoolCalidusKeys = endpoint{
+	name:        "pool_calidus_keys",
+	method:      http.MethodGet,
+	category:    categoryPool,
+	description: "Pool Calidus Keys",
+	info:        "List of latest valid calidus keys for all pools",
+	usage:       "koios api pool_calidus_keys",
+}
+
+// endpointScriptInfo is definition of POST /script_info command.
+var endpointScriptInfo = endpoint{
+	name:        "script_info",
+	method:      http.MethodPost,
+	category:    categoryScript,
+	description: "Script Information",
+	info:        "List of script information for given script hashes",
+	usage:       "koios api script_info [_script_hashes...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointNativeScriptList is definition of GET /native_script_list command.
+var endpointNativeScriptList = endpoint{
+	name:        "native_script_list",
+	method:      http.MethodGet,
+	category:    categoryScript,
+	description: "Native Script List",
+	info:        "List of all existing native script hashes along with their creation transaction hashes",
+	usage:       "koios api native_script_list",
+}
+
+// endpointPlutusScriptList is definition of GET /plutus_script_list command.
+var endpointPlutusScriptList = endpoint{
+	name:        "plutus_script_list",
+	method:      http.MethodGet,
+	category:    categoryScript,
+	description: "Plutus Script List",
+	info:        "List of all existing Plutus script hashes along with their creation transaction hashes",
+	usage:       "koios api plutus_script_list",
+}
+
+// endpointScriptRedeemers is definition of GET /script_redeemers command.
+var endpointScriptRedeemers = endpoint{
+	name:        "script_redeemers",
+	method:      http.MethodGet,
+	category:    categoryScript,
+	description: "Script Redeemers",
+	info:        "List of all redeemers for a given script hash",
+	usage:       "koios api script_redeemers [_script_hash]",
+	argnMin:     1,
+	argnMax:     1,
+}
+
+// endpointScriptUtxos is definition of GET /script_utxos command.
+var endpointScriptUtxos = endpoint{
+	name:        "script_utxos",
+	method:      http.MethodGet,
+	category:    categoryScript,
+	description: "Script UTXOs",
+	info:        "List of all UTXOs for a given script hash",
+	usage:       "koios api script_utxos [_script_hash]",
+	argnMin:     1,
+	argnMax:     1,
+	flags: []varflag.FlagCreateFunc{
+		extendedFlag,
+	},
+	params: map[string]string{
+		"extended": "_extended",
+	},
+}
+
+// endpointDatumInfo is definition of POST /datum_info command.
+var endpointDatumInfo = endpoint{
+	name:        "datum_info",
+	method:      http.MethodPost,
+	category:    categoryScript,
+	description: "Datum Information",
+	info:        "List of datum information for given datum hashes",
+	usage:       "koios api datum_info [_datum_hashes...]",
+	argnMax:     batchArgnMax,
+	batchSize:   50,
+}
+
+// endpointOgmios is definition of POST /ogmios command.
+var endpointOgmios = endpoint{
+	name:        "ogmios",
+	method:      http.MethodPost,
+	category:    categoryOgmios,
+	description: "Query Ogmios JSON-RPC",
+	info:        "Query current network state and evaluate transactions with stateless Ogmios JSON-RPC methods",
+	usage:       "koios api ogmios",
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+
+	"github.com/cardano-community/koios-cli/v2/internal/auth"
+	"github.com/cardano-community/koios-go-client/v4"
+	"github.com/happy-sdk/happy"
+)
+
+// Error categories reported in code field of error output.
+const (
+	errCodeError       = "error"
+	errCodeUsage       = "usage"
+	errCodeAuth        = "auth"
+	errCodeRateLimited = "rate-limited"
+	errCodeNotFound    = "not-found"
+	errCodeNetwork     = "network"
+	errCodeServer      = "server"
+	errCodeDecode      = "decode"
+)
+
+// exitCodes are process exit codes of error categories.
+var exitCodes = map[string]int{
+	errCodeError:       1,
+	errCodeUsage:       2,
+	errCodeAuth:        3,
+	errCodeRateLimited: 4,
+	errCodeNotFound:    5,
+	errCodeNetwork:     6,
+	errCodeServer:      7,
+	errCodeDecode:      8,
+}
+
+type apiError struct {
+	Error     string `json:"error"`
+	Code      string `json:"code"`
+	Status    int    `json:"status,omitempty"`
+	Endpoint  string `json:"endpoint,omitempty"`
+	Retryable bool   `json:"retryable"`
+	Details   any    `json:"details,omitempty"`
+}
+
+// categoryError sets category of the error.
+type categoryError struct {
+	code string
+	err  error
+}
+
+func (e *categoryError) Error() string { return e.err.Error() }
+func (e *categoryError) Unwrap() error { return e.err }
+
+// categorize sets category of err unless err already has one.
+func categorize(code string, err error) error {
+	var ce *categoryError
+	if err == nil || errors.As(err, &ce) {
+		return err
+	}
+	return &categoryError{code: code, err: err}
+}
+
+// usageErrorf returns error of invalid command usage.
+func usageErrorf(format string, a ...any) error {
+	return &categoryError{code: errCodeUsage, err: fmt.Errorf(format, a...)}
+}
+
+// requestError is error of the api request with status and endpoint
+// of the response.
+type requestError struct {
+	status   int
+	endpoint string
+	err      error
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+func (e *requestError) Unwrap() error { return e.err }
+
+// requestErr adds status and endpoint of koios response res to err.
+func requestErr(res any, err error) error {
+	var re *requestError
+	if err == nil || errors.As(err, &re) {
+		return err
+	}
+	re = &requestError{err: err}
+	v := reflect.ValueOf(res)
+	if v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+		if r, ok := v.Elem().FieldByName("Response").Interface().(koios.Response); ok {
+			re.status = r.StatusCode
+			if u, err := url.Parse(r.RequestURL); err == nil {
+				re.endpoint = u.Path
+			}
+		}
+	}
+	return re
+}
+
+// newAPIError describes err with its category.
+func newAPIError(err error) apiError {
+	e := apiError{Error: err.Error(), Code: errCodeError}
+	var re *requestError
+	if errors.As(err, &re) {
+		e.Status, e.Endpoint = re.status, re.endpoint
+	}
+	var (
+		ce *categoryError
+		oe *ogmiosError
+	)
+	switch {
+	case errors.As(err, &ce):
+		e.Code = ce.code
+	case errors.As(err, &oe):
+		e.Details = oe
+		// JSON-RPC errors of invalid request, method or params
+		if oe.Code >= -32700 && oe.Code <= -32600 {
+			e.Code = errCodeUsage
+		}
+	case e.Status == http.StatusUnauthorized, e.Status == http.StatusForbidden,
+		errors.Is(err, koios.ErrAuth), errors.Is(err, auth.ErrTokenExpired):
+		e.Code = errCodeAuth
+	case e.Status == http.StatusTooManyRequests:
+		e.Code, e.Retryable = errCodeRateLimited, true
+	case errors.Is(err, ErrQuotaExceeded), errors.Is(err, errNoTokens):
+		e.Code = errCodeRateLimited
+	case e.Status == http.StatusNotFound, errors.Is(err, koios.ErrNoData):
+		e.Code = errCodeNotFound
+	case e.Status >= http.StatusInternalServerError:
+		e.Code = errCodeServer
+		e.Retryable = e.Status == http.StatusBadGateway ||
+			e.Status == http.StatusServiceUnavailable ||
+			e.Status == http.StatusGatewayTimeout
+	case e.Status >= http.StatusBadRequest:
+		e.Code = errCodeUsage
+	case failover(context.Background(), nil, err):
+		e.Code, e.Retryable = errCodeNetwork, true
+	case isDecodeErr(err):
+		e.Code = errCodeDecode
+	}
+	return e
+}
+
+func isDecodeErr(err error) bool {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	return errors.As(err, &syntaxErr) ||
+		errors.As(err, &typeErr) ||
+		errors.Is(err, koios.ErrResponseIsNotJSON) ||
+		errors.Is(err, koios.ErrUnexpectedResponseField)
+}
+
+// exitCode returns exit code of error category of err.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return exitCodes[newAPIError(err).Code]
+}
+
+var (
+	exitMu  sync.Mutex
+	exitErr error
+)
+
+// ExitCode returns process exit code of error category of the error api
+// command failed with, 0 when it succeeded. Happy exits with code 1 on any
+// error, main exits with the code once happy has shut down.
+func ExitCode() int {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	return exitCode(exitErr)
+}
+
+// setExitErr records error of api command for ExitCode.
+func setExitErr(err error) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	exitErr = err
+}
+
+// addSubCommand adds api command which error is recorded for ExitCode.
+func addSubCommand(cmd, sub *happy.Command) {
+	sub.AfterAlways(func(sess *happy.Session, err error) error {
+		setExitErr(err)
+		return nil
+	})
+	cmd.AddSubCommand(sub)
+}
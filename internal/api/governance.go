@@ -0,0 +1,373 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/cardano-community/koios-go-client/v4"
+	"github.com/happy-sdk/happy"
+	"github.com/happy-sdk/happy/pkg/vars/varflag"
+)
+
+const categoryGovernance = "governance"
+
+// Governance:
+// https://api.koios.rest/#tag--Governance
+func governance(cmd *happy.Command, c *client) {
+	cmd.DescribeCategory(categoryGovernance, "Query information about Conway era governance")
+	addSubCommand(cmd, cmdGovernanceDrepEpochSummary(c))
+	addSubCommand(cmd, cmdGovernanceDrepList(c))
+	addSubCommand(cmd, cmdGovernanceDrepInfo(c))
+	addSubCommand(cmd, cmdGovernanceDrepMetadata(c))
+	addSubCommand(cmd, cmdGovernanceDrepUpdates(c))
+	addSubCommand(cmd, cmdGovernanceDrepVotes(c))
+	addSubCommand(cmd, cmdGovernanceDrepDelegators(c))
+	addSubCommand(cmd, cmdGovernanceDrepVotingPowerHistory(c))
+	addSubCommand(cmd, cmdGovernanceCommitteeInfo(c))
+	addSubCommand(cmd, cmdGovernanceCommitteeVotes(c))
+	addSubCommand(cmd, cmdGovernancePoolVotes(c))
+	addSubCommand(cmd, cmdGovernanceProposalList(c))
+	addSubCommand(cmd, cmdGovernanceVoterProposalList(c))
+	addSubCommand(cmd, cmdGovernanceProposalVotingSummary(c))
+	addSubCommand(cmd, cmdGovernanceProposalVotes(c))
+}
+
+func cmdGovernanceDrepEpochSummary(c *client) *happy.Command {
+	cmd := endpointDrepEpochSummary.command().WithFlags(pagingFlags...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api drep_epoch_summary
+    Example: koios-cli api drep_epoch_summary --epoch 520
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			endpointDrepEpochSummary.query(args, opts)
+			return c.get(ctx, "/drep_epoch_summary", opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdGovernanceDrepList(c *client) *happy.Command {
+	cmd := endpointDrepList.command().WithFlags(pagingFlags...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api drep_list
+    Example: koios-cli api drep_list --all
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.get(ctx, "/drep_list", opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdGovernanceDrepInfo(c *client) *happy.Command {
+	cmd := endpointDrepInfo.command().WithFlags(slices.Concat(pagingFlags, flagSlice(fromFileFlag))...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api drep_info \
+      drep1yfhyq6tztjksqqpd5lglc3zr2tn8vylgjh9xzz7n2p4l4lgk3qam3 \
+      drep_always_abstain
+
+    Read DRep ids from file (newline, comma separated or json array) or stdin with -
+
+    Example: koios-cli api drep_list --all -o ndjson | jq -r .drep_id | koios-cli api drep_info -
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.batch(sess, args, endpointDrepInfo, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.post(ctx, "/drep_info", map[string][]string{"_drep_ids": batch}, opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdGovernanceDrepMetadata(c *client) *happy.Command {
+	cmd := endpointDrepMetadata.command().WithFlags(slices.Concat(pagingFlags, flagSlice(fromFileFlag))...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api drep_metadata \
+      drep1yfhyq6tztjksqqpd5lglc3zr2tn8vylgjh9xzz7n2p4l4lgk3qam3
+
+    Example: koios-cli api drep_metadata --from-file drep-ids.txt
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.batch(sess, args, endpointDrepMetadata, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.post(ctx, "/drep_metadata", map[string][]string{"_drep_ids": batch}, opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdGovernanceDrepUpdates(c *client) *happy.Command {
+	cmd := endpointDrepUpdates.command().WithFlags(pagingFlags...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api drep_updates
+    Example: koios-cli api drep_updates drep1yfhyq6tztjksqqpd5lglc3zr2tn8vylgjh9xzz7n2p4l4lgk3qam3
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			if id := args.Arg(0).String(); id != "" {
+				opts.QuerySet("_drep_id", id)
+			}
+			return c.get(ctx, "/drep_updates", opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdGovernanceDrepVotes(c *client) *happy.Command {
+	cmd := endpointDrepVotes.command().WithFlags(pagingFlags...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api drep_votes drep1yfhyq6tztjksqqpd5lglc3zr2tn8vylgjh9xzz7n2p4l4lgk3qam3
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			opts.QuerySet("_drep_id", args.Arg(0).String())
+			return c.get(ctx, "/drep_votes", opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdGovernanceDrepDelegators(c *client) *happy.Command {
+	cmd := endpointDrepDelegators.command().WithFlags(pagingFlags...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api drep_delegators drep1yfhyq6tztjksqqpd5lglc3zr2tn8vylgjh9xzz7n2p4l4lgk3qam3
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			opts.QuerySet("_drep_id", args.Arg(0).String())
+			return c.get(ctx, "/drep_delegators", opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdGovernanceDrepVotingPowerHistory(c *client) *happy.Command {
+	cmd := endpointDrepVotingPowerHistory.command().WithFlags(pagingFlags...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api drep_voting_power_history drep1yfhyq6tztjksqqpd5lglc3zr2tn8vylgjh9xzz7n2p4l4lgk3qam3
+    Example: koios-cli api drep_voting_power_history --epoch 520 --all
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			if id := args.Arg(0).String(); id != "" {
+				opts.QuerySet("_drep_id", id)
+			}
+			endpointDrepVotingPowerHistory.query(args, opts)
+			return c.get(ctx, "/drep_voting_power_history", opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdGovernanceCommitteeInfo(c *client) *happy.Command {
+	cmd := endpointCommitteeInfo.command()
+
+	cmd.AddInfo(`
+    Example: koios-cli api committee_info
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		opts, err := c.newRequestOpts(sess, args)
+		if err != nil {
+			return err
+		}
+
+		res, err := c.get(sess, "/committee_info", opts)
+		return c.output(res, err)
+	})
+
+	return cmd
+}
+
+func cmdGovernanceCommitteeVotes(c *client) *happy.Command {
+	cmd := endpointCommitteeVotes.command().WithFlags(pagingFlags...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api committee_votes
+    Example: koios-cli api committee_votes cc_hot1qgqs2ydmk5ctnt6yvt3wgwlfahdkhrkvkshkk5a9ds3ce3dgxn0qr
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			if id := args.Arg(0).String(); id != "" {
+				opts.QuerySet("_cc_hot_id", id)
+			}
+			return c.get(ctx, "/committee_votes", opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdGovernancePoolVotes(c *client) *happy.Command {
+	cmd := endpointPoolVotes.command().WithFlags(pagingFlags...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api pool_votes pool155efqn9xpcf73pphkk88cmlkdwx4ulkg606tne970qswczg3asc
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			opts.QuerySet("_pool_bech32", args.Arg(0).String())
+			return c.get(ctx, "/pool_votes", opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdGovernanceProposalList(c *client) *happy.Command {
+	cmd := endpointProposalList.command().WithFlags(pagingFlags...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api proposal_list
+    Example: koios-cli api proposal_list --all -o ndjson
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.get(ctx, "/proposal_list", opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdGovernanceVoterProposalList(c *client) *happy.Command {
+	cmd := endpointVoterProposalList.command().WithFlags(pagingFlags...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api voter_proposal_list drep1yfhyq6tztjksqqpd5lglc3zr2tn8vylgjh9xzz7n2p4l4lgk3qam3
+    Example: koios-cli api voter_proposal_list pool155efqn9xpcf73pphkk88cmlkdwx4ulkg606tne970qswczg3asc
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			opts.QuerySet("_voter_id", args.Arg(0).String())
+			return c.get(ctx, "/voter_proposal_list", opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdGovernanceProposalVotingSummary(c *client) *happy.Command {
+	cmd := endpointProposalVotingSummary.command(
+		happy.Option("argn.min", 0),
+		happy.Option("argn.max", batchArgnMax),
+		happy.Option("usage", "koios api proposal_voting_summary [_proposal_id...]"),
+	).WithFlags(
+		fromFileFlag,
+		varflag.BoolFunc("tally", false, "Write DRep, SPO and CC tally of each proposal with ratification thresholds"),
+	)
+
+	cmd.AddInfo(`
+    With --tally yes, no and abstain votes of DReps, SPOs and constitutional
+    committee are written as rows of proposal with stake-weighted percentages,
+    ratification threshold of the voter group and whether the threshold is met.
+    DRep and SPO thresholds are read from epoch_params of the summary epoch and
+    committee threshold is quorum of current committee from committee_info.
+    Parameters changed by parameter change are read from proposal_list, DRep
+    threshold is the highest threshold of their groups and SPOs vote only when
+    security parameter is changed. Thresholds are empty for unknown parameters.
+
+    Example: koios-cli api proposal_voting_summary gov_action1pvv5wmjqhwa4u85vu9f4ydmzu2mgt8n7et967ph2urhx53r70xusqnmm525
+    Example: koios-cli api proposal_voting_summary --tally -o table \
+      gov_action1pvv5wmjqhwa4u85vu9f4ydmzu2mgt8n7et967ph2urhx53r70xusqnmm525
+    Example: koios-cli api proposal_list --all -o ndjson | jq -r .proposal_id \
+      | koios-cli api proposal_voting_summary --tally -o table -
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		ids, err := argValues(args)
+		if err != nil {
+			return categorize(errCodeUsage, err)
+		}
+		if len(ids) == 0 {
+			return usageErrorf("no proposal ids, provide them as arguments, with --from-file or with - to read from stdin")
+		}
+		if err := c.reserveRequests(uint(len(ids))); err != nil {
+			return err
+		}
+
+		var (
+			merged    any
+			summaries []votingSummary
+		)
+		for _, id := range ids {
+			opts, err := c.newRequestOpts(sess, args)
+			if err != nil {
+				return err
+			}
+			opts.QuerySet("_proposal_id", id)
+			res, err := c.get(sess, "/proposal_voting_summary", opts)
+			if err != nil {
+				return c.output(res, err)
+			}
+			appendResponseData(&merged, res)
+			for _, record := range res.Data {
+				summary := votingSummary{ProposalID: id}
+				if err := json.Unmarshal(record, &summary); err != nil {
+					return c.output(res, err)
+				}
+				summaries = append(summaries, summary)
+			}
+		}
+		if !args.Flag("tally").Var().Bool() {
+			apiOutput(c.out, merged, nil)
+			return nil
+		}
+
+		tally, err := c.votingTally(sess, args, summaries)
+		apiOutput(c.out, tally, err)
+		return err
+	})
+
+	return cmd
+}
+
+func cmdGovernanceProposalVotes(c *client) *happy.Command {
+	cmd := endpointProposalVotes.command().WithFlags(pagingFlags...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api proposal_votes gov_action1pvv5wmjqhwa4u85vu9f4ydmzu2mgt8n7et967ph2urhx53r70xusqnmm525
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			opts.QuerySet("_proposal_id", args.Arg(0).String())
+			return c.get(ctx, "/proposal_votes", opts)
+		})
+	})
+
+	return cmd
+}
@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cardano-community/koios-go-client/v4"
+	"github.com/happy-sdk/happy/sdk/logging"
+)
+
+const (
+	// maxBlockLag is number of blocks host can be behind the highest
+	// tip of probed hosts before it is considered lagging.
+	maxBlockLag = 10
+	// maxProbeTimeout limits time tip of single host is probed.
+	maxProbeTimeout = 5 * time.Second
+)
+
+// failoverTransport sends requests to the first working host of the
+// network within timeout of single attempt. Request failing with connection
+// error, timeout or 5xx response is retried with the next host and the host
+// which answered is used for following requests. Requests which are not
+// idempotent, see idempotent, are sent to the next host only when the
+// connection to the host could not be established.
+type failoverTransport struct {
+	mu    sync.Mutex
+	hosts []string
+	// current is index of host requests are sent to first.
+	current int
+	// timeout of single attempt, whole request is limited by http client.
+	timeout time.Duration
+	next    http.RoundTripper
+	log     logging.Logger
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := bufferBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	start := t.current
+	t.mu.Unlock()
+
+	var (
+		res  *http.Response
+		idx  int
+		idem = idempotent(req, body)
+	)
+	for i := range t.hosts {
+		idx = (start + i) % len(t.hosts)
+		if res != nil {
+			res.Body.Close()
+		}
+		r := withBody(req.Clone(req.Context()), body)
+		r.URL.Host, r.Host = t.hosts[idx], ""
+		if res, err = t.attempt(r); !failover(req.Context(), res, err) || (!idem && !dialFailed(err)) {
+			break
+		}
+		reason := fmt.Sprint(err)
+		if err == nil {
+			reason = res.Status
+		}
+		if i < len(t.hosts)-1 {
+			t.log.Warn("host failed, failing over to next host",
+				slog.String("host", t.hosts[idx]),
+				slog.String("next", t.hosts[(idx+1)%len(t.hosts)]),
+				slog.String("reason", reason),
+			)
+		}
+	}
+	if err == nil && idx != start {
+		t.mu.Lock()
+		t.current = idx
+		t.mu.Unlock()
+	}
+	return res, err
+}
+
+// attempt sends request to single host within per attempt timeout.
+func (t *failoverTransport) attempt(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	res, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// failover reports whether request should be retried with next host.
+// Requests canceled by caller are not retried.
+func failover(ctx context.Context, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		var netErr net.Error
+		return errors.As(err, &netErr) ||
+			errors.Is(err, context.DeadlineExceeded) ||
+			errors.Is(err, io.EOF) ||
+			errors.Is(err, io.ErrUnexpectedEOF)
+	}
+	return res.StatusCode >= http.StatusInternalServerError
+}
+
+// dialFailed reports whether err is failure to connect to the host,
+// so the request was not sent.
+func dialFailed(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// cancelBody cancels context of the request when response body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// bufferBody reads body of the request so it can be sent more than once.
+func bufferBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+// withBody sets buffered body on clone of the request.
+func withBody(req *http.Request, body []byte) *http.Request {
+	if body != nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+	}
+	return req
+}
+
+// hostAddr returns address of host the same way koios client sets it.
+func hostAddr(host string, port uint16) string {
+	if port == 80 || port == 443 {
+		return host
+	}
+	return fmt.Sprint(host, ":", port)
+}
+
+type hostProbe struct {
+	host    string
+	latency time.Duration
+	blockNo koios.BlockNo
+	err     error
+}
+
+// probeHosts requests tip from each host and orders hosts by latency.
+// Hosts which failed or are lagging behind the highest tip are moved last.
+// Probes use public tier and are not counted against the subscription.
+func probeHosts(ctx context.Context, s *settings, hosts []string, log logging.Logger) []string {
+	timeout := min(s.timeout, maxProbeTimeout)
+	probes := make([]hostProbe, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		probes[i].host = host
+		wg.Add(1)
+		go func(p *hostProbe) {
+			defer wg.Done()
+			kc, err := koios.New(
+				koios.APIVersion(s.apiVersion),
+				koios.Scheme(s.scheme),
+				koios.Host(p.host),
+				koios.Origin(s.origin),
+				koios.Port(s.port),
+				koios.Timeout(timeout),
+			)
+			if err != nil {
+				p.err = err
+				return
+			}
+			started := time.Now()
+			res, err := kc.GetTip(ctx, nil)
+			p.latency = time.Since(started)
+			if err != nil {
+				p.err = err
+				return
+			}
+			p.blockNo = res.Data.BlockNo
+		}(&probes[i])
+	}
+	wg.Wait()
+
+	var tip koios.BlockNo
+	for _, p := range probes {
+		if p.err == nil {
+			tip = max(tip, p.blockNo)
+		}
+	}
+	// rank 0 healthy, 1 lagging, 2 failed
+	rank := func(p hostProbe) int {
+		switch {
+		case p.err != nil:
+			return 2
+		case p.blockNo+maxBlockLag < tip:
+			return 1
+		}
+		return 0
+	}
+	sort.SliceStable(probes, func(i, j int) bool {
+		if ri, rj := rank(probes[i]), rank(probes[j]); ri != rj {
+			return ri < rj
+		}
+		return probes[i].err == nil && probes[i].latency < probes[j].latency
+	})
+
+	ordered := make([]string, len(probes))
+	for i, p := range probes {
+		ordered[i] = p.host
+		attrs := []slog.Attr{
+			slog.String("host", p.host),
+			slog.Duration("latency", p.latency),
+			slog.Uint64("block_no", uint64(p.blockNo)),
+		}
+		switch rank(p) {
+		case 1:
+			attrs = append(attrs, slog.Uint64("behind", uint64(tip-p.blockNo)))
+			log.Warn("host is lagging behind", attrs...)
+		case 2:
+			log.Warn("host probe failed", append(attrs, slog.String("err", p.err.Error()))...)
+		default:
+			log.Debug("host probe", attrs...)
+		}
+	}
+	log.Debug("using host", slog.String("host", ordered[0]))
+	return ordered
+}
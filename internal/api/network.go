@@ -5,6 +5,7 @@
 package api
 
 import (
+	"context"
 	"slices"
 
 	"github.com/cardano-community/koios-go-client/v4"
@@ -17,23 +18,18 @@ const categoryNetwork = "network"
 // https://api.koios.rest/#tag--Network
 func network(cmd *happy.Command, c *client) {
 	cmd.DescribeCategory(categoryNetwork, "Query information about the network")
-	cmd.AddSubCommand(cmdNetworkTip(c))
-	cmd.AddSubCommand(cmdNetworkGenesis(c))
-	cmd.AddSubCommand(cmdNetworkTotals(c))
-	cmd.AddSubCommand(cmdNetworkParamUpdates(c))
-	cmd.AddSubCommand(cmdNetworkReserveWithdrawals(c))
-	cmd.AddSubCommand(cmdNetworkTreasuryWithdrawals(c))
+	addSubCommand(cmd, cmdNetworkTip(c))
+	addSubCommand(cmd, cmdNetworkGenesis(c))
+	addSubCommand(cmd, cmdNetworkTotals(c))
+	addSubCommand(cmd, cmdNetworkParamUpdates(c))
+	addSubCommand(cmd, cmdNetworkCliProtocolParams(c))
+	addSubCommand(cmd, cmdNetworkReserveWithdrawals(c))
+	addSubCommand(cmd, cmdNetworkTreasuryWithdrawals(c))
 }
 
 func cmdNetworkTip(c *client) *happy.Command {
-	cmd := happy.NewCommand("tip",
-		happy.Option("description", "Query Chain Tip"),
-		happy.Option("category", categoryNetwork),
-	).WithFlags(queryFlag)
-	cmd.AddInfo("Get the tip info about the latest block seen by chain")
+	cmd := endpointTip.command().WithFlags(queryFlag)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/tip
-
   Example: koios-cli api tip
     {
       ...
@@ -67,22 +63,15 @@ func cmdNetworkTip(c *client) *happy.Command {
 		}
 
 		res, err := c.koios().GetTip(sess, opts)
-		apiOutput(c.noFormat, res, err)
-		return nil
+		return c.output(res, err)
 	})
 
 	return cmd
 }
 
 func cmdNetworkGenesis(c *client) *happy.Command {
-	cmd := happy.NewCommand("genesis",
-		happy.Option("description", "Get Genesis info"),
-		happy.Option("category", categoryNetwork),
-	).WithFlags(queryFlag)
-	cmd.AddInfo("Get the Genesis parameters used to start specific era on chain")
+	cmd := endpointGenesis.command().WithFlags(queryFlag)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/genesis
-
   Example: koios-cli api genesis --query="select=networkmagic,networkid"
     {
       ...
@@ -109,26 +98,15 @@ func cmdNetworkGenesis(c *client) *happy.Command {
 		}
 
 		res, err := c.koios().GetGenesis(sess, opts)
-		apiOutput(c.noFormat, res, err)
-		return nil
+		return c.output(res, err)
 	})
 
 	return cmd
 }
 
 func cmdNetworkTotals(c *client) *happy.Command {
-	cmd := happy.NewCommand("totals",
-		happy.Option("description", "Get historical tokenomic stats"),
-		happy.Option("category", categoryNetwork),
-	).WithFlags(
-		slices.Concat(pagingFlags, flagSlice(epochNoFlag))...,
-	)
-	cmd.AddInfo(`
-  Get the circulating utxo, treasury rewards, supply and reserves in lovelace
-  for specified epoch, all epochs if --epoch is not set`)
+	cmd := endpointTotals.command().WithFlags(pagingFlags...)
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/totals
-
   Example: koios-cli api totals
   Example: koios-cli api totals --page 1 --page-size 5
   Example: koios-cli api totals --epoch 469
@@ -156,102 +134,87 @@ func cmdNetworkTotals(c *client) *happy.Command {
 			}
 		}
 
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		res, err := c.koios().GetTotals(sess, epoch, opts)
-		apiOutput(c.noFormat, res, err)
-		return nil
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetTotals(ctx, epoch, opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdNetworkParamUpdates(c *client) *happy.Command {
-	cmd := happy.NewCommand("param_updates",
-		happy.Option("description", "Param Update Proposals"),
-		happy.Option("category", categoryNetwork),
-	).WithFlags(
+	cmd := endpointParamUpdates.command().WithFlags(
 		slices.Concat(pagingFlags, flagSlice(queryFlag))...,
 	)
-	cmd.AddInfo("Get all parameter update proposals submitted to the chain starting Shelley era")
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/param_updates
-
   Example:
     koios-cli api param_updates --page 1 --page-size 3
     koios-cli api param_updates --query="order=block_height.desc&limit=1"
     koios-cli api param_updates --query="epoch_no=eq.444"
   `)
 
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetParamUpdates(ctx, opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdNetworkCliProtocolParams(c *client) *happy.Command {
+	cmd := endpointCliProtocolParams.command()
+	cmd.AddInfo(`
+  Example: koios-cli api cli_protocol_params
+  Example: koios-cli api cli_protocol_params --no-format | jq .data > protocol.json
+  `)
+
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
 		opts, err := c.newRequestOpts(sess, args)
 		if err != nil {
 			return err
 		}
 
-		res, err := c.koios().GetParamUpdates(sess, opts)
-		apiOutput(c.noFormat, res, err)
-		return nil
+		res, err := c.getValue(sess, "/cli_protocol_params", opts)
+		return c.output(res, err)
 	})
 
 	return cmd
 }
 
 func cmdNetworkReserveWithdrawals(c *client) *happy.Command {
-	cmd := happy.NewCommand("reserve_withdrawals",
-		happy.Option("description", "Reserve Withdrawals"),
-		happy.Option("category", categoryNetwork),
-	).WithFlags(
+	cmd := endpointReserveWithdrawals.command().WithFlags(
 		slices.Concat(pagingFlags, flagSlice(queryFlag))...,
 	)
-	cmd.AddInfo("List of all withdrawals from reserves against stake accounts")
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/reserve_withdrawals
-
   Example:
     koios-cli api reserve_withdrawals --page 1 --page-size 3
     koios-cli api reserve_withdrawals --page-size 3 --query="epoch_no=eq.285"
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		res, err := c.koios().GetReserveWithdrawals(sess, opts)
-		apiOutput(c.noFormat, res, err)
-		return nil
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetReserveWithdrawals(ctx, opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdNetworkTreasuryWithdrawals(c *client) *happy.Command {
-	cmd := happy.NewCommand("treasury_withdrawals",
-		happy.Option("description", "Treasury Withdrawals"),
-		happy.Option("category", categoryNetwork),
-	).WithFlags(
+	cmd := endpointTreasuryWithdrawals.command().WithFlags(
 		slices.Concat(pagingFlags, flagSlice(queryFlag))...,
 	)
-	cmd.AddInfo("List of all withdrawals from treasury against stake accounts")
 	cmd.AddInfo(`
-  Docs: https://api.koios.rest/#get-/treasury_withdrawals
-
   Example:
     koios-cli api treasury_withdrawals --page 1 --page-size 3
     koios-cli api treasury_withdrawals --page 1 --page-size 3 --query "order=block_height.desc"
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-		res, err := c.koios().GetTreasuryWithdrawals(sess, opts)
-		apiOutput(c.noFormat, res, err)
-		return nil
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetTreasuryWithdrawals(ctx, opts)
+		})
 	})
 
 	return cmd
@@ -4,13 +4,159 @@
 
 package api
 
-import "github.com/happy-sdk/happy"
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 
-const categoryOgmios = "ogmios"
+	"github.com/cardano-community/koios-go-client/v4"
+	"github.com/happy-sdk/happy"
+)
+
+const (
+	categoryOgmios = "ogmios"
+
+	ogmiosEvaluateTransaction = "evaluateTransaction"
+)
+
+// ogmiosShortcuts are names of common Ogmios queries,
+// named not to clash with names of api commands.
+var ogmiosShortcuts = map[string]string{
+	"network-tip":         "queryNetwork/tip",
+	"protocol-parameters": "queryLedgerState/protocolParameters",
+	"era-summaries":       "queryLedgerState/eraSummaries",
+	"evaluate":            ogmiosEvaluateTransaction,
+}
 
 // Ogmios:
 // https://api.koios.rest/#tag--Ogmios
 func ogmios(cmd *happy.Command, c *client) {
 	cmd.DescribeCategory(categoryOgmios, "Various stateless queries against Ogmios v6 instance")
-	cmd.AddSubCommand(notimplCmd(categoryOgmios, "ogmios"))
+	addSubCommand(cmd, cmdOgmios(c))
+}
+
+func cmdOgmios(c *client) *happy.Command {
+	cmd := endpointOgmios.command(
+		happy.Option("argn.min", 1),
+		happy.Option("argn.max", 2),
+		happy.Option("usage", "koios api ogmios <method> [params-json]"),
+	)
+
+	var shortcuts []string
+	for name, method := range ogmiosShortcuts {
+		shortcuts = append(shortcuts, fmt.Sprintf("%-20s %s", name, method))
+	}
+	sort.Strings(shortcuts)
+	cmd.AddInfo(`
+    Method can be given as one of shortcuts:
+
+      ` + strings.Join(shortcuts, "\n      ") + `
+
+    Params of evaluateTransaction can be given as signed transaction file,
+    or - to read transaction from stdin, see submittx for supported formats.
+    JSON-RPC errors are written as error output with Ogmios error in details.
+
+    Docs: https://ogmios.dev/api/
+
+    Example: koios-cli api ogmios network-tip
+    Example: koios-cli api ogmios queryLedgerState/epoch
+    Example: koios-cli api ogmios queryLedgerState/stakePools '{"stakePools":[{"id":"pool1..."}]}'
+    Example: koios-cli api ogmios evaluate tx.signed
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		method := args.Arg(0).String()
+		if m, ok := ogmiosShortcuts[method]; ok {
+			method = m
+		}
+		var params json.RawMessage
+		if raw := strings.TrimSpace(args.Arg(1).String()); method == ogmiosEvaluateTransaction && !strings.HasPrefix(raw, "{") {
+			tx, err := readTx(raw)
+			if err != nil {
+				return categorize(errCodeUsage, err)
+			}
+			if _, err := txHash(tx); err != nil {
+				return usageErrorf("input is not valid transaction: %w", err)
+			}
+			params, _ = json.Marshal(map[string]any{"transaction": map[string]string{"cbor": hex.EncodeToString(tx)}})
+		} else if raw != "" {
+			if !json.Valid([]byte(raw)) {
+				return usageErrorf("params of method %s are not valid json", method)
+			}
+			params = json.RawMessage(raw)
+		}
+
+		opts, err := c.newRequestOpts(sess, args)
+		if err != nil {
+			return err
+		}
+		res, err := c.ogmiosQuery(sess, method, params, opts)
+		return c.output(res, err)
+	})
+
+	return cmd
+}
+
+// ogmiosResponse is response of /ogmios endpoint, data is result of the query.
+type ogmiosResponse struct {
+	koios.Response
+	Data json.RawMessage `json:"data"`
+}
+
+// ogmiosError is JSON-RPC error returned by Ogmios.
+type ogmiosError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *ogmiosError) Error() string {
+	return fmt.Sprintf("ogmios error %d: %s", e.Code, e.Message)
+}
+
+// ogmiosQuery sends JSON-RPC request to /ogmios endpoint.
+func (c *client) ogmiosQuery(ctx context.Context, method string, params json.RawMessage, opts *koios.RequestOptions) (*ogmiosResponse, error) {
+	res := &ogmiosResponse{}
+	req := struct {
+		JSONRPC string          `json:"jsonrpc"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params,omitempty"`
+	}{"2.0", method, params}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return res, err
+	}
+
+	rsp, reqErr := c.koios().POST(ctx, "/ogmios", bytes.NewReader(body), opts)
+	if rsp == nil {
+		return res, reqErr
+	}
+	applyResponse(&res.Response, rsp)
+
+	data, err := koios.ReadResponseBody(rsp)
+	if err != nil {
+		return res, err
+	}
+	var msg struct {
+		Result json.RawMessage `json:"result"`
+		Error  *ogmiosError    `json:"error"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		if reqErr != nil {
+			return res, reqErr
+		}
+		return res, fmt.Errorf("%w: %w", koios.ErrResponseIsNotJSON, err)
+	}
+	if msg.Error != nil {
+		return res, msg.Error
+	}
+	if reqErr != nil {
+		return res, reqErr
+	}
+	res.Data = msg.Result
+	return res, nil
 }
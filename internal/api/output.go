@@ -0,0 +1,276 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+
+	"github.com/happy-sdk/happy/pkg/strings/textfmt"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultOutputFormat = "json"
+
+// outputFormatter writes data to w in specific output format.
+type outputFormatter func(w io.Writer, opts outputOptions, data any) error
+
+// outputFormatters is registry of supported --output formats.
+var outputFormatters = map[string]outputFormatter{
+	"json":   formatJSON,
+	"ndjson": formatNDJSON,
+	"yaml":   formatYAML,
+	"csv":    formatCSV,
+	"table":  formatTable,
+}
+
+type outputOptions struct {
+	format   string
+	noFormat bool
+}
+
+// outputFormats returns sorted list of registered output formats.
+func outputFormats() []string {
+	var formats []string
+	for name := range outputFormatters {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+func formatJSON(w io.Writer, opts outputOptions, data any) error {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+
+	if !opts.noFormat {
+		encoder.SetIndent("", "  ")
+	}
+
+	if err := encoder.Encode(data); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, buffer.String())
+	return err
+}
+
+// formatNDJSON writes each record on its own line as compact json.
+func formatNDJSON(w io.Writer, opts outputOptions, data any) error {
+	records, err := outputRecords(data)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		buffer := &bytes.Buffer{}
+		if err := json.Compact(buffer, record); err != nil {
+			return err
+		}
+		buffer.WriteByte('\n')
+		if _, err := w.Write(buffer.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatYAML writes full response as yaml document, field order
+// of the json response is preserved.
+func formatYAML(w io.Writer, opts outputOptions, data any) error {
+	raw, err := marshalJSON(data)
+	if err != nil {
+		return err
+	}
+	node := &yaml.Node{}
+	if err := yaml.Unmarshal(raw, node); err != nil {
+		return err
+	}
+	resetYAMLStyle(node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// resetYAMLStyle drops json flow and quoting styles
+// so that node is encoded as block style yaml.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		resetYAMLStyle(n)
+	}
+}
+
+func formatCSV(w io.Writer, opts outputOptions, data any) error {
+	columns, rows, err := outputRows(data)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func formatTable(w io.Writer, opts outputOptions, data any) error {
+	columns, rows, err := outputRows(data)
+	if err != nil {
+		return err
+	}
+	tbl := textfmt.Table{
+		WithHeader: true,
+	}
+	tbl.AddRow(columns...)
+	for _, row := range rows {
+		tbl.AddRow(row...)
+	}
+	_, err = fmt.Fprint(w, tbl.String())
+	return err
+}
+
+// outputRecords returns records of the koios response data.
+// When data has "data" field then elements of that field are returned,
+// otherwise data itself is returned as single record.
+func outputRecords(data any) ([]json.RawMessage, error) {
+	raw, err := marshalJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &envelope); err == nil {
+		if d, ok := envelope["data"]; ok {
+			raw = d
+		}
+	}
+
+	raw = bytes.TrimSpace(raw)
+	switch {
+	case bytes.Equal(raw, []byte("null")):
+		return nil, nil
+	case len(raw) > 0 && raw[0] == '[':
+		var records []json.RawMessage
+		if err := json.Unmarshal(raw, &records); err != nil {
+			return nil, err
+		}
+		return records, nil
+	default:
+		return []json.RawMessage{raw}, nil
+	}
+}
+
+// outputRows flattens response records into rows. Nested objects are
+// flattened to dotted column names and arrays are kept as compact json.
+func outputRows(data any) (columns []string, rows [][]string, err error) {
+	records, err := outputRecords(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var flattened []map[string]string
+	for _, record := range records {
+		var fields []outputField
+		if err := flattenJSON("", record, &fields); err != nil {
+			return nil, nil, err
+		}
+		row := make(map[string]string, len(fields))
+		for _, field := range fields {
+			if !slices.Contains(columns, field.key) {
+				columns = append(columns, field.key)
+			}
+			row[field.key] = field.value
+		}
+		flattened = append(flattened, row)
+	}
+
+	for _, record := range flattened {
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = record[col]
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows, nil
+}
+
+type outputField struct {
+	key   string
+	value string
+}
+
+func flattenJSON(prefix string, raw json.RawMessage, fields *[]outputField) error {
+	raw = bytes.TrimSpace(raw)
+	key := prefix
+	if key == "" {
+		key = "value"
+	}
+
+	switch {
+	case len(raw) == 0 || bytes.Equal(raw, []byte("null")):
+		*fields = append(*fields, outputField{key, ""})
+	case raw[0] == '{':
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		n := 0
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			name, _ := tok.(string)
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+			var val json.RawMessage
+			if err := dec.Decode(&val); err != nil {
+				return err
+			}
+			if err := flattenJSON(name, val, fields); err != nil {
+				return err
+			}
+			n++
+		}
+		if n == 0 {
+			*fields = append(*fields, outputField{key, ""})
+		}
+	case raw[0] == '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return err
+		}
+		*fields = append(*fields, outputField{key, s})
+	default:
+		buffer := &bytes.Buffer{}
+		if err := json.Compact(buffer, raw); err != nil {
+			return err
+		}
+		*fields = append(*fields, outputField{key, buffer.String()})
+	}
+	return nil
+}
+
+func marshalJSON(data any) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(data); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
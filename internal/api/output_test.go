@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestOutputRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		columns []string
+		rows    [][]string
+	}{
+		{
+			name:    "nested objects",
+			data:    `[{"tx_hash":"ab","block":{"height":10,"epoch":{"no":500}}}]`,
+			columns: []string{"tx_hash", "block.height", "block.epoch.no"},
+			rows:    [][]string{{"ab", "10", "500"}},
+		},
+		{
+			name:    "arrays as compact json",
+			data:    `[{"addresses":[ "addr1", "addr2" ],"assets":[{"policy_id":"p","quantity":"1"}]}]`,
+			columns: []string{"addresses", "assets"},
+			rows:    [][]string{{`["addr1","addr2"]`, `[{"policy_id":"p","quantity":"1"}]`}},
+		},
+		{
+			name:    "null and empty object",
+			data:    `[{"metadata":null,"script":{}}]`,
+			columns: []string{"metadata", "script"},
+			rows:    [][]string{{"", ""}},
+		},
+		{
+			name:    "columns of all records",
+			data:    `[{"a":1},{"b":{"c":true}},{"a":2,"b":{"c":false}}]`,
+			columns: []string{"a", "b.c"},
+			rows:    [][]string{{"1", ""}, {"", "true"}, {"2", "false"}},
+		},
+		{
+			name:    "data of response",
+			data:    `{"request_url":"/tip","data":[{"epoch_no":500}]}`,
+			columns: []string{"epoch_no"},
+			rows:    [][]string{{"500"}},
+		},
+		{
+			name:    "single object",
+			data:    `{"epoch_no":500,"hash":"ab"}`,
+			columns: []string{"epoch_no", "hash"},
+			rows:    [][]string{{"500", "ab"}},
+		},
+		{
+			name:    "array of values",
+			data:    `["ab","cd"]`,
+			columns: []string{"value"},
+			rows:    [][]string{{"ab"}, {"cd"}},
+		},
+		{
+			name: "null",
+			data: `null`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, rows, err := outputRows(json.RawMessage(tt.data))
+			if err != nil {
+				t.Fatalf("outputRows() error: %v", err)
+			}
+			if !reflect.DeepEqual(columns, tt.columns) {
+				t.Errorf("outputRows() columns = %q, want %q", columns, tt.columns)
+			}
+			if !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("outputRows() rows = %q, want %q", rows, tt.rows)
+			}
+		})
+	}
+}
+
+func TestFormatCSV(t *testing.T) {
+	data := json.RawMessage(`[{"tx_hash":"ab","block":{"height":10},"inputs":[{"value":"1"}]}]`)
+	want := "tx_hash,block.height,inputs\n" +
+		`ab,10,"[{""value"":""1""}]"` + "\n"
+
+	var buf bytes.Buffer
+	if err := formatCSV(&buf, outputOptions{format: "csv"}, data); err != nil {
+		t.Fatalf("formatCSV() error: %v", err)
+	}
+	if buf.String() != want {
+		t.Errorf("formatCSV() = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"syscall"
+
+	"github.com/cardano-community/koios-go-client/v4"
+	"github.com/happy-sdk/happy"
+)
+
+// streamFormats are output formats which are written page by page
+// as pages arrive when requesting all pages.
+var streamFormats = []string{"ndjson"}
+
+// pageFunc requests single page from the koios api.
+type pageFunc func(ctx context.Context, opts *koios.RequestOptions) (any, error)
+
+// pageHandler handles response of single page. It returns number of records
+// in the page and false when response has no records to paginate.
+type pageHandler func(res any) (int, bool, error)
+
+// paginate requests page set by paging flags and outputs the response.
+// When --all flag is set it keeps requesting next pages until page with
+// less than page size records is returned. All page requests go through
+// the client rate limiter.
+//
+// Pages are merged into single response unless output format is one of
+// streamFormats, then records of each page are written as soon as page arrives.
+// On interrupt (Ctrl-C) records received so far are written before returning.
+func (c *client) paginate(sess *happy.Session, args happy.Args, fetch pageFunc) error {
+	ctx, stop := signal.NotifyContext(sess, os.Interrupt)
+	defer stop()
+
+	stream := args.Flag("all").Var().Bool() && slices.Contains(streamFormats, c.out.format)
+	var w *bufio.Writer
+	if stream {
+		// while SIGPIPE is notified, writes to closed stdout e.g. when piped
+		// to head fail with EPIPE instead of the process being killed.
+		// Default handling is restored on return.
+		sigpipe := make(chan os.Signal, 1)
+		signal.Notify(sigpipe, syscall.SIGPIPE)
+		defer signal.Stop(sigpipe)
+		w = bufio.NewWriter(os.Stdout)
+		defer w.Flush()
+	}
+
+	var merged any
+	pages, records, err := c.fetchPages(ctx, sess, args, fetch, func(res any) (int, bool, error) {
+		if !stream {
+			n, ok := appendResponseData(&merged, res)
+			return n, ok, nil
+		}
+		var n int
+		data, ok := responseData(res)
+		if ok {
+			n = data.Len()
+		}
+		if err := outputFormatters[c.out.format](w, c.out, res); err != nil {
+			return n, ok, err
+		}
+		return n, ok, w.Flush()
+	})
+	if err != nil {
+		if brokenPipe(err) {
+			sess.Log().Debug("output closed, stopped requesting pages",
+				slog.Uint64("pages", uint64(pages)),
+				slog.Int("records", records),
+			)
+			return nil
+		}
+		if ctx.Err() != nil {
+			sess.Log().Warn("interrupted, output contains only pages received so far",
+				slog.Uint64("pages", uint64(pages)),
+				slog.Int("records", records),
+			)
+			if !stream && merged != nil {
+				apiOutput(c.out, merged, nil)
+			}
+			return fmt.Errorf("interrupted: %w", ctx.Err())
+		}
+		apiOutput(c.out, nil, err)
+		return err
+	}
+
+	if !stream {
+		apiOutput(c.out, merged, nil)
+	}
+	return nil
+}
+
+// brokenPipe reports whether err is write error of closed output.
+func brokenPipe(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, io.ErrClosedPipe)
+}
+
+// fetchPages requests page set by paging flags and passes the response to handle.
+// When --all flag is set it keeps requesting next pages until page with less than
+// page size records is returned or --max-pages is reached. It returns number of
+// pages and records received. Number of remaining pages is estimated from the
+// first page and request is aborted when they would not fit into daily quota.
+func (c *client) fetchPages(ctx context.Context, sess *happy.Session, args happy.Args, fetch pageFunc, handle pageHandler) (pages uint, records int, err error) {
+	if !args.Flag("all").Var().Bool() {
+		opts, err := c.newRequestOpts(sess, args)
+		if err != nil {
+			return 0, 0, err
+		}
+		res, err := fetch(ctx, opts)
+		if err != nil {
+			return 0, 0, requestErr(res, err)
+		}
+		n, _, err := handle(res)
+		return 1, n, err
+	}
+
+	pageSize := koios.PageSize
+	if args.Flag("page-size").Present() {
+		pageSize = args.Flag("page-size").Var().Uint()
+	}
+	page := uint(1)
+	if args.Flag("page").Present() {
+		page = args.Flag("page").Var().Uint()
+	}
+	maxPages := args.Flag("max-pages").Var().Uint()
+
+	for {
+		opts, err := c.newRequestOpts(sess, args)
+		if err != nil {
+			return pages, records, err
+		}
+		opts.SetCurrentPage(page)
+		opts.SetPageSize(pageSize)
+		if pages == 0 {
+			// ask for total count to estimate number of requests
+			opts.HeaderSet("Prefer", "count=estimated")
+		}
+
+		sess.Log().Debug("requesting page", slog.Uint64("page", uint64(page)), slog.Uint64("page-size", uint64(pageSize)))
+		res, err := fetch(ctx, opts)
+		if err != nil {
+			return pages, records, requestErr(res, err)
+		}
+		n, ok, err := handle(res)
+		if err != nil {
+			return pages, records, err
+		}
+		pages++
+		records += n
+
+		if !ok || n < int(pageSize) {
+			return pages, records, nil
+		}
+		if pages == 1 {
+			if remaining, ok := remainingPages(res, pageSize); ok {
+				if maxPages > 0 {
+					remaining = min(remaining, maxPages-1)
+				}
+				sess.Log().Debug("estimated remaining pages", slog.Uint64("pages", uint64(remaining)))
+				if err := c.reserveRequests(remaining); err != nil {
+					return pages, records, err
+				}
+			}
+		}
+		if maxPages > 0 && pages >= maxPages {
+			sess.Log().Warn("stopped after reaching --max-pages, more results may be available",
+				slog.Uint64("max-pages", uint64(maxPages)),
+				slog.Uint64("last-page", uint64(page)),
+			)
+			return pages, records, nil
+		}
+		page++
+	}
+}
+
+// appendResponseData appends Data slice of res to Data slice of merged response.
+// First response becomes the merged response. It returns number of records in
+// res and false when res has no Data slice to paginate.
+func appendResponseData(merged *any, res any) (int, bool) {
+	data, ok := responseData(res)
+	if *merged == nil {
+		*merged = res
+		if !ok {
+			return 0, false
+		}
+		return data.Len(), true
+	}
+	if !ok {
+		return 0, false
+	}
+	dest, _ := responseData(*merged)
+	dest.Set(reflect.AppendSlice(dest, data))
+	return data.Len(), true
+}
+
+// responseData returns settable Data slice field of koios response.
+func responseData(res any) (reflect.Value, bool) {
+	v := reflect.ValueOf(res)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return reflect.Value{}, false
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	data := v.FieldByName("Data")
+	if !data.IsValid() || data.Kind() != reflect.Slice {
+		return reflect.Value{}, false
+	}
+	return data, true
+}
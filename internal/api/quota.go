@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/cardano-community/koios-go-client/v4"
+	"github.com/happy-sdk/happy/sdk/logging"
+)
+
+const (
+	defaultQuotaWarn  = 80
+	defaultQuotaLimit = 100
+)
+
+var ErrQuotaExceeded = errors.New("daily request quota exceeded")
+
+// quota enforces daily request limit of the subscription tier
+// before requests are sent to the koios api.
+type quota struct {
+	// max is daily request limit of the tier, 0 when tier has no limit.
+	max uint
+	// warn and limit are soft and hard thresholds in percent of max.
+	warn   uint
+	limit  uint
+	force  bool
+	warned bool
+	log    logging.Logger
+	// attrs are added to logged warnings e.g. profile of token pool.
+	attrs []slog.Attr
+}
+
+func newQuota(authInfo koios.AuthInfo, warn, limit uint, force bool, log logging.Logger) (*quota, error) {
+	if limit == 0 {
+		return nil, errors.New("--quota-limit must be greater than 0")
+	}
+	if warn > limit {
+		return nil, fmt.Errorf("--quota-warn %d%% can not be greater than --quota-limit %d%%", warn, limit)
+	}
+	q := &quota{
+		warn:  warn,
+		limit: limit,
+		force: force,
+		log:   log,
+	}
+	// custom tier has no request limit
+	if authInfo.MaxRequests != math.MaxUint {
+		q.max = authInfo.MaxRequests
+	}
+	return q, nil
+}
+
+// check returns ErrQuotaExceeded when n more requests on top of used
+// requests would exceed hard threshold, unless --force is set.
+// It warns once when soft threshold is reached.
+func (q *quota) check(used, n uint) error {
+	if q == nil || q.max == 0 {
+		return nil
+	}
+	if remaining := q.remaining(used); n > remaining {
+		if q.force {
+			if !q.warned {
+				q.warned = true
+				q.log.Warn("daily request quota exceeded, continuing with --force", append(q.attrs,
+					slog.Uint64("requests-today", uint64(used)),
+					slog.Uint64("max-requests", uint64(q.max)),
+				)...)
+			}
+			return nil
+		}
+		return fmt.Errorf("%w: %d requests needed, %d of %d daily requests remaining (--quota-limit %d%%), use --force to send anyway",
+			ErrQuotaExceeded, n, remaining, q.max, q.limit)
+	}
+	if !q.warned && used+n >= q.max*q.warn/100 {
+		q.warned = true
+		q.log.Warn("approaching daily request quota", append(q.attrs,
+			slog.Uint64("requests-today", uint64(used+n)),
+			slog.Uint64("max-requests", uint64(q.max)),
+			slog.String("used", fmt.Sprintf("%d%%", (used+n)*100/q.max)),
+		)...)
+	}
+	return nil
+}
+
+// remaining returns number of requests left until hard threshold.
+func (q *quota) remaining(used uint) uint {
+	if q == nil || q.max == 0 {
+		return math.MaxUint
+	}
+	hard := q.max * q.limit / 100
+	return hard - min(used, hard)
+}
+
+// reserveRequests checks whether n more requests fit into remaining daily quota.
+// It is used to abort early before operations requiring many requests.
+func (c *client) reserveRequests(n uint) error {
+	if c.tokens != nil {
+		return c.tokens.reserve(n)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.subscription == nil {
+		return nil
+	}
+	return c.quota.check(c.subscription.RequestsToday, n)
+}
+
+// remainingPages estimates number of pages left to request after
+// first page of res, from total count of Content-Range response header.
+// It returns false when total count is unknown.
+func remainingPages(res any, pageSize uint) (uint, bool) {
+	v := reflect.ValueOf(res)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return 0, false
+	}
+	cr := v.Elem().FieldByName("ContentRange")
+	if !cr.IsValid() || cr.Kind() != reflect.String {
+		return 0, false
+	}
+	// e.g. 0-999/12345
+	rng, total, ok := strings.Cut(cr.String(), "/")
+	if !ok || pageSize == 0 {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(total, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	_, end, _ := strings.Cut(rng, "-")
+	last, err := strconv.ParseUint(end, 10, 64)
+	if err != nil || last+1 >= n {
+		return 0, err == nil
+	}
+	return uint((n - last - 1 + uint64(pageSize) - 1) / uint64(pageSize)), true
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cardano-community/koios-go-client/v4"
+)
+
+// recordsResponse is response of endpoint which koios client has no
+// method for. Records are kept as returned by the api, so their fields
+// are written in same order.
+type recordsResponse struct {
+	koios.Response
+	Data []json.RawMessage `json:"data"`
+}
+
+// valueResponse is response of endpoint which koios client has no method
+// for, returning single json value instead of records.
+type valueResponse struct {
+	koios.Response
+	Data json.RawMessage `json:"data"`
+}
+
+// get requests records of endpoint path with query params set in opts.
+func (c *client) get(ctx context.Context, path string, opts *koios.RequestOptions) (*recordsResponse, error) {
+	res := &recordsResponse{}
+	return res, c.request(ctx, http.MethodGet, path, nil, opts, &res.Response, &res.Data)
+}
+
+// post requests records of endpoint path with payload as json body.
+func (c *client) post(ctx context.Context, path string, payload any, opts *koios.RequestOptions) (*recordsResponse, error) {
+	res := &recordsResponse{}
+	return res, c.request(ctx, http.MethodPost, path, payload, opts, &res.Response, &res.Data)
+}
+
+// getValue requests json value of endpoint path with query params set in opts.
+func (c *client) getValue(ctx context.Context, path string, opts *koios.RequestOptions) (*valueResponse, error) {
+	res := &valueResponse{}
+	return res, c.request(ctx, http.MethodGet, path, nil, opts, &res.Response, &res.Data)
+}
+
+// request sends request to endpoint path and decodes response body into data.
+func (c *client) request(ctx context.Context, method, path string, payload any, opts *koios.RequestOptions, res *koios.Response, data any) error {
+	var (
+		rsp *http.Response
+		err error
+	)
+	if method == http.MethodPost {
+		body, merr := json.Marshal(payload)
+		if merr != nil {
+			return merr
+		}
+		rsp, err = c.koios().POST(ctx, path, bytes.NewReader(body), opts)
+	} else {
+		rsp, err = c.koios().GET(ctx, path, opts)
+	}
+	if rsp == nil {
+		return err
+	}
+	applyResponse(res, rsp)
+	if err != nil {
+		_ = rsp.Body.Close()
+		return err
+	}
+	return koios.ReadAndUnmarshalResponse(rsp, res, data)
+}
+
+// applyResponse sets request and response headers of rsp to res,
+// as koios client does for responses of its methods.
+func applyResponse(res *koios.Response, rsp *http.Response) {
+	res.RequestURL = rsp.Request.URL.String()
+	res.RequestMethod = rsp.Request.Method
+	res.StatusCode = rsp.StatusCode
+	res.Status = rsp.Status
+	res.Date = rsp.Header.Get("date")
+	res.ContentRange = rsp.Header.Get("content-range")
+	res.ContentLocation = rsp.Header.Get("content-location")
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/happy-sdk/happy/sdk/logging"
+)
+
+const (
+	defaultRetries      = 2
+	defaultRetryBackoff = time.Second
+	// maxRetryAfter is longest Retry-After request is retried after,
+	// longer waits return the response.
+	maxRetryAfter = time.Minute
+)
+
+// retryTransport retries idempotent requests failing with transient
+// error. Delay between attempts is doubled after each retry unless
+// server sets Retry-After header. Each attempt is sent to the api,
+// so it is counted against the subscription.
+type retryTransport struct {
+	retries uint
+	backoff time.Duration
+	next    http.RoundTripper
+	log     logging.Logger
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := bufferBody(req)
+	if err != nil {
+		return nil, err
+	}
+	attempts := t.retries + 1
+	if !idempotent(req, body) {
+		attempts = 1
+	}
+
+	backoff := t.backoff
+	for attempt := uint(1); ; attempt++ {
+		res, err := t.next.RoundTrip(withBody(req.Clone(req.Context()), body))
+		attrs := []slog.Attr{
+			slog.String("attempt", fmt.Sprintf("%d/%d", attempt, attempts)),
+			slog.String("method", req.Method),
+			slog.String("endpoint", req.URL.Path),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("err", err.Error()))
+		} else {
+			attrs = append(attrs, slog.Int("status", res.StatusCode))
+		}
+		if attempt >= attempts || !retryable(req.Context(), res, err) {
+			t.log.Debug("request", attrs...)
+			return res, err
+		}
+
+		wait := backoff
+		if err == nil {
+			if after, ok := retryAfter(res); ok {
+				if after > maxRetryAfter {
+					t.log.Debug("request", append(attrs, slog.Duration("retry-after", after))...)
+					return res, nil
+				}
+				wait = after
+			}
+			res.Body.Close()
+		}
+		t.log.Debug("request failed, retrying", append(attrs, slog.Duration("wait", wait))...)
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// idempotent reports whether request can be safely sent again.
+// Koios POST endpoints are queries except transaction submission.
+func idempotent(req *http.Request, body []byte) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		return !strings.HasSuffix(req.URL.Path, "/submittx") &&
+			!bytes.Contains(body, []byte("submitTransaction"))
+	}
+	return false
+}
+
+// retryable reports whether request failed with transient error.
+func retryable(ctx context.Context, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return failover(ctx, res, err)
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns delay set by Retry-After header of the response,
+// either in seconds or as http date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	value := res.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(value); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
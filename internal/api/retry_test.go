@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/happy-sdk/happy/sdk/logging"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func testResponse(status int, retryAfter string) *http.Response {
+	res := &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+	if retryAfter != "" {
+		res.Header.Set("Retry-After", retryAfter)
+	}
+	return res
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		min   time.Duration
+		max   time.Duration
+		ok    bool
+	}{
+		{name: "not set"},
+		{name: "seconds", value: "3", min: 3 * time.Second, max: 3 * time.Second, ok: true},
+		{name: "zero seconds", value: "0", ok: true},
+		{name: "negative seconds", value: "-1"},
+		{name: "invalid", value: "soon"},
+		// http date has precision of seconds
+		{
+			name:  "http date",
+			value: time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat),
+			min:   28 * time.Second,
+			max:   30 * time.Second,
+			ok:    true,
+		},
+		{name: "past http date", value: "Sun, 06 Nov 1994 08:49:37 GMT", ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after, ok := retryAfter(testResponse(http.StatusTooManyRequests, tt.value))
+			if ok != tt.ok || after < tt.min || after > tt.max {
+				t.Errorf("retryAfter(%q) = %s, %t, want %s-%s, %t", tt.value, after, ok, tt.min, tt.max, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRetryTransportRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		requests   int
+		status     int
+	}{
+		// backoff of the transport is longer than test timeout,
+		// so request is retried after Retry-After
+		{name: "retried after", retryAfter: "0", requests: 2, status: http.StatusOK},
+		{
+			name:       "over cap",
+			retryAfter: strconv.Itoa(int((maxRetryAfter + time.Second) / time.Second)),
+			requests:   1,
+			status:     http.StatusTooManyRequests,
+		},
+		{
+			name:       "http date over cap",
+			retryAfter: time.Now().Add(2 * maxRetryAfter).UTC().Format(http.TimeFormat),
+			requests:   1,
+			status:     http.StatusTooManyRequests,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			transport := &retryTransport{
+				retries: 1,
+				backoff: time.Hour,
+				log:     logging.NewTestLogger(logging.LevelError),
+				next: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					requests++
+					if requests == 1 {
+						return testResponse(http.StatusTooManyRequests, tt.retryAfter), nil
+					}
+					return testResponse(http.StatusOK, ""), nil
+				}),
+			}
+			req, err := http.NewRequest(http.MethodGet, "http://localhost/api/v1/tip", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() error: %v", err)
+			}
+			if requests != tt.requests || res.StatusCode != tt.status {
+				t.Errorf("RoundTrip() = %d after %d requests, want %d after %d", res.StatusCode, requests, tt.status, tt.requests)
+			}
+		})
+	}
+}
@@ -5,11 +5,11 @@
 package api
 
 import (
+	"context"
 	"slices"
 
 	"github.com/cardano-community/koios-go-client/v4"
 	"github.com/happy-sdk/happy"
-	"github.com/happy-sdk/happy/pkg/vars/varflag"
 )
 
 const categoryScript = "script"
@@ -18,137 +18,78 @@ const categoryScript = "script"
 // https://api.koios.rest/#tag--Script
 func script(cmd *happy.Command, c *client) {
 	cmd.DescribeCategory(categoryScript, "Query information about specific scripts (Smart Contracts)")
-	cmd.AddSubCommand(cmdScriptScriptInfo(c))
-	cmd.AddSubCommand(cmdScriptNativeScriptList(c))
-	cmd.AddSubCommand(cmdScriptPlutusScriptList(c))
-	cmd.AddSubCommand(cmdScriptScriptRedeemers(c))
-	cmd.AddSubCommand(cmdScriptScriptUtxos(c))
-	cmd.AddSubCommand(cmdScriptDatumInfo(c))
+	addSubCommand(cmd, cmdScriptScriptInfo(c))
+	addSubCommand(cmd, cmdScriptNativeScriptList(c))
+	addSubCommand(cmd, cmdScriptPlutusScriptList(c))
+	addSubCommand(cmd, cmdScriptScriptRedeemers(c))
+	addSubCommand(cmd, cmdScriptScriptUtxos(c))
+	addSubCommand(cmd, cmdScriptDatumInfo(c))
 }
 
 func cmdScriptScriptInfo(c *client) *happy.Command {
-	cmd := happy.NewCommand("script_info",
-		happy.Option("description", "Script Information"),
-		happy.Option("category", categoryScript),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-		happy.Option("usage", "koios api script_info [_script_hashes...] // max 50"),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("List of script information for given script hashes")
+	cmd := endpointScriptInfo.command().WithFlags(slices.Concat(pagingFlags, flagSlice(fromFileFlag))...)
 
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#post-/script_info
-
     Example: koios-cli api script_info \
       bd2119ee2bfb8c8d7c427e8af3c35d537534281e09e23013bca5b138 \
       c0c671fba483641a71bb92d3a8b7c52c90bf1c01e2b83116ad7d4536
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-
-		var hashes []koios.ScriptHash
-		for _, arg := range args.Args() {
-			hashes = append(hashes, koios.ScriptHash(arg.String()))
-		}
-
-		res, err := c.koios().GetScriptInfo(sess, hashes, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.batch(sess, args, endpointScriptInfo, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetScriptInfo(ctx, argsOf[koios.ScriptHash](batch), opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdScriptNativeScriptList(c *client) *happy.Command {
-	cmd := happy.NewCommand("native_script_list",
-		happy.Option("description", "Native Script List"),
-		happy.Option("category", categoryScript),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("List of all existing native script hashes along with their creation transaction hashes.")
+	cmd := endpointNativeScriptList.command().WithFlags(pagingFlags...)
 
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#get-/native_script_list
-
     Example: koios-cli api native_script_list
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-
-		res, err := c.koios().GetNativeScripts(sess, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetNativeScripts(ctx, opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdScriptPlutusScriptList(c *client) *happy.Command {
-	cmd := happy.NewCommand("plutus_script_list",
-		happy.Option("description", "Plutus Script List"),
-		happy.Option("category", categoryScript),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("List of all existing Plutus script hashes along with their creation transaction hashes.")
+	cmd := endpointPlutusScriptList.command().WithFlags(pagingFlags...)
 
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#get-/plutus_script_list
-
     Example: koios-cli api plutus_script_list
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-
-		res, err := c.koios().GetPlutusScripts(sess, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetPlutusScripts(ctx, opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdScriptScriptRedeemers(c *client) *happy.Command {
-	cmd := happy.NewCommand("script_redeemers",
-		happy.Option("description", "Script Redeemers"),
-		happy.Option("category", categoryScript),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 1),
-		happy.Option("usage", "koios api script_redeemers [_script_hash]"),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("List of all redeemers for a given script hash.")
+	cmd := endpointScriptRedeemers.command().WithFlags(pagingFlags...)
 
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#get-/script_redeemers
-
     Example: koios-cli api script_redeemers d8480dc869b94b80e81ec91b0abe307279311fe0e7001a9488f61ff8
 
     Example: koios-cli api script_redeemers d8480dc869b94b80e81ec91b0abe307279311fe0e7001a9488f61ff8 --page 1 --page-size 3
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-
 		hash := koios.ScriptHash(args.Arg(0).String())
-		res, err := c.koios().GetScriptRedeemers(sess, hash, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetScriptRedeemers(ctx, hash, opts)
+		})
 
 	})
 
@@ -156,24 +97,9 @@ func cmdScriptScriptRedeemers(c *client) *happy.Command {
 }
 
 func cmdScriptScriptUtxos(c *client) *happy.Command {
-	cmd := happy.NewCommand("script_utxos",
-		happy.Option("description", "Script Utxos"),
-		happy.Option("category", categoryScript),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 1),
-		happy.Option("usage", "koios api script_utxos [_script_hash]"),
-	).WithFlags(
-		slices.Concat(
-			pagingFlags,
-			flagSlice(
-				varflag.BoolFunc("extended", false, "Controls whether or not certain optional fields supported by a given endpoint are populated as a part of the call"),
-			))...)
-
-	cmd.AddInfo("List of all UTXOs for a given script hash.")
+	cmd := endpointScriptUtxos.command().WithFlags(pagingFlags...)
 
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#get-/script_utxos
-
     Example: koios-cli api script_utxos d8480dc869b94b80e81ec91b0abe307279311fe0e7001a9488f61ff8
     Example: koios-cli api script_utxos d8480dc869b94b80e81ec91b0abe307279311fe0e7001a9488f61ff8 --extended
     Example: koios-cli api script_utxos d8480dc869b94b80e81ec91b0abe307279311fe0e7001a9488f61ff8 --page 1 --page-size 3
@@ -181,15 +107,10 @@ func cmdScriptScriptUtxos(c *client) *happy.Command {
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-
 		hash := koios.ScriptHash(args.Arg(0).String())
-		res, err := c.koios().GetScriptUtxos(sess, hash, args.Flag("extended").Var().Bool(), opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetScriptUtxos(ctx, hash, args.Flag("extended").Var().Bool(), opts)
+		})
 	})
 
 	return cmd
@@ -197,19 +118,9 @@ func cmdScriptScriptUtxos(c *client) *happy.Command {
 
 func cmdScriptDatumInfo(c *client) *happy.Command {
 
-	cmd := happy.NewCommand("datum_info",
-		happy.Option("description", "Datum Information"),
-		happy.Option("category", categoryScript),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-		happy.Option("usage", "koios api datum_info [_datum_hashes...] // max 50"),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("List of datum information for given datum hashes")
+	cmd := endpointDatumInfo.command().WithFlags(slices.Concat(pagingFlags, flagSlice(fromFileFlag))...)
 
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#post-/datum_info
-
     Example: koios-cli api datum_info \
       818ee3db3bbbd04f9f2ce21778cac3ac605802a4fcb00c8b3a58ee2dafc17d46 \
       45b0cfc220ceec5b7c1c62c4d4193d38e4eba48e8815729ce75f9c0ab0e4c1c0
@@ -218,19 +129,9 @@ func cmdScriptDatumInfo(c *client) *happy.Command {
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
 
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-
-		var hashes []koios.DatumHash
-		for _, arg := range args.Args() {
-			hashes = append(hashes, koios.DatumHash(arg.String()))
-		}
-
-		res, err := c.koios().GetDatumInfos(sess, hashes, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.batch(sess, args, endpointDatumInfo, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetDatumInfos(ctx, argsOf[koios.DatumHash](batch), opts)
+		})
 	})
 
 	return cmd
@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cardano-community/koios-cli/v2/internal/auth"
+	"github.com/cardano-community/koios-cli/v2/internal/config"
+	"github.com/happy-sdk/happy"
+)
+
+// networkSourcePrefix prefixes source of settings set by network preset.
+const networkSourcePrefix = "network "
+
+// networkAliases are --host-<network> flags kept as aliases of --network.
+var networkAliases = map[string]string{
+	"host-eu":       "mainnet-eu",
+	"host-preview":  "preview",
+	"host-preprod":  "preprod",
+	"host-guildnet": "guildnet",
+}
+
+// settings are api client settings resolved from flags,
+// environment variables, config files and flag defaults.
+type settings struct {
+	network    string
+	apiVersion string
+	scheme     string
+	host       string
+	// hosts are fallback hosts tried in order after host fails.
+	hosts     []string
+	port      uint16
+	origin    string
+	rateLimit int
+	timeout   time.Duration
+	retries   uint
+	backoff   time.Duration
+}
+
+// settingsResolver resolves value of api flag in order of precedence:
+// flag, environment variable, network preset, setting of config file
+// and last flag default. Source of each value is logged.
+type settingsResolver struct {
+	sess    *happy.Session
+	args    happy.Args
+	cfg     *config.Config
+	network *config.Network
+}
+
+func (r *settingsResolver) get(key string) (string, error) {
+	value, source := r.lookup(key)
+	if source != config.SourceDefault {
+		if err := config.Validate(key, value); err != nil {
+			if source == config.SourceEnv {
+				source = config.Env(key)
+			}
+			return "", fmt.Errorf("%s: %w", source, err)
+		}
+	}
+	r.sess.Log().Debug("setting",
+		slog.String("key", key),
+		slog.String("value", value),
+		slog.String("source", source),
+	)
+	return value, nil
+}
+
+// source returns source of the value of key.
+func (r *settingsResolver) source(key string) string {
+	_, source := r.lookup(key)
+	return source
+}
+
+func (r *settingsResolver) lookup(key string) (value, source string) {
+	if r.args.Flag(key).Present() {
+		return r.args.Flag(key).String(), config.SourceFlag
+	}
+	if value := os.Getenv(config.Env(key)); value != "" {
+		return value, config.SourceEnv
+	}
+	if r.network != nil {
+		if value, source, ok := r.network.Get(key); ok {
+			return value, networkSourcePrefix + r.network.Name + " " + source
+		}
+	}
+	if value, source, ok := r.cfg.Get(key); ok {
+		return value, source
+	}
+	return r.args.Flag(key).String(), config.SourceDefault
+}
+
+// resolveSettings resolves api client settings. Config file of the profile
+// is read together with global config file.
+func resolveSettings(sess *happy.Session, args happy.Args) (*settings, error) {
+	cfg, err := config.Load(sess, configProfile(sess, args))
+	if err != nil {
+		return nil, err
+	}
+	r := &settingsResolver{sess: sess, args: args, cfg: cfg}
+
+	s := &settings{}
+	var value string
+	if value, err = networkAlias(args); err != nil {
+		return nil, err
+	}
+	if value != "" {
+		sess.Log().Debug("setting", slog.String("key", "network"), slog.String("value", value), slog.String("source", config.SourceFlag))
+	} else if value, err = r.get("network"); err != nil {
+		return nil, err
+	}
+	if r.network, err = cfg.Network(value); err != nil {
+		return nil, err
+	}
+	s.network = r.network.Name
+	if s.apiVersion, err = r.get("api-version"); err != nil {
+		return nil, err
+	}
+	if s.scheme, err = r.get("scheme"); err != nil {
+		return nil, err
+	}
+	if s.host, err = r.get("host"); err != nil {
+		return nil, err
+	}
+	if value, err = r.get("hosts"); err != nil {
+		return nil, err
+	}
+	// fallback hosts of the network are used only with host of the network,
+	// so other host never fails over to hosts of another network.
+	if host := r.source("host"); host == config.SourceDefault || fromNetwork(host) || !fromNetwork(r.source("hosts")) {
+		s.hosts = hostNames(s.host, value)
+	}
+	if value, err = r.get("port"); err != nil {
+		return nil, err
+	}
+	port, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q: %w", value, err)
+	}
+	s.port = uint16(port)
+	if s.origin, err = r.get("origin"); err != nil {
+		return nil, err
+	}
+	if value, err = r.get("rate-limit"); err != nil {
+		return nil, err
+	}
+	if s.rateLimit, err = strconv.Atoi(value); err != nil {
+		return nil, fmt.Errorf("invalid rate limit %q: %w", value, err)
+	}
+	if value, err = r.get("timeout"); err != nil {
+		return nil, err
+	}
+	if s.timeout, err = time.ParseDuration(value); err != nil {
+		return nil, fmt.Errorf("invalid timeout %q: %w", value, err)
+	}
+	if value, err = r.get("retries"); err != nil {
+		return nil, err
+	}
+	retries, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid retries %q: %w", value, err)
+	}
+	s.retries = uint(retries)
+	if value, err = r.get("retry-backoff"); err != nil {
+		return nil, err
+	}
+	if s.backoff, err = time.ParseDuration(value); err != nil {
+		return nil, fmt.Errorf("invalid retry backoff %q: %w", value, err)
+	}
+	return s, nil
+}
+
+// fromNetwork reports whether source of setting is network preset.
+func fromNetwork(source string) bool {
+	return strings.HasPrefix(source, networkSourcePrefix)
+}
+
+// hostNames parses comma separated list of fallback hosts
+// skipping duplicates and primary host.
+func hostNames(primary, list string) []string {
+	var hosts []string
+	for _, host := range strings.Split(list, ",") {
+		host = strings.TrimSpace(host)
+		if host != "" && host != primary && !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// networkAlias returns network selected with --host-<network> alias flag.
+func networkAlias(args happy.Args) (string, error) {
+	var (
+		network   string
+		hostflags int
+	)
+	if args.Flag("host").Present() {
+		hostflags++
+	}
+	for flag, name := range networkAliases {
+		if args.Flag(flag).Present() && args.Flag(flag).Var().Bool() {
+			network = name
+			hostflags++
+		}
+	}
+	if hostflags > 1 {
+		return "", usageErrorf("only one host flag can be used")
+	}
+	if network != "" && args.Flag("network").Present() && args.Flag("network").String() != network {
+		return "", usageErrorf("host flag selects network %s, which conflicts with --network %s", network, args.Flag("network").String())
+	}
+	return network, nil
+}
+
+// configProfile returns profile which config file is used,
+// the profile selected same way as in client.configureAuth.
+func configProfile(sess *happy.Session, args happy.Args) string {
+	switch {
+	case args.Flag("profile").Present():
+		return sess.Get("app.profile.name").String()
+	case args.Flag("profiles").Present(), args.Flag("auth").Present(), os.Getenv(auth.AuthEnv) != "":
+		return ""
+	}
+	// errors are reported when profile is loaded
+	name, _, _, _ := auth.ActiveProfile(sess)
+	return name
+}
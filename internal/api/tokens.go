@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cardano-community/koios-cli/v2/internal/auth"
+	"github.com/happy-sdk/happy/sdk/logging"
+)
+
+// defaultTokenCooldown is time token is skipped after rate limited
+// response without Retry-After header.
+const defaultTokenCooldown = time.Second
+
+var errNoTokens = errors.New("no usable tokens left in --profiles")
+
+// tokenPool rotates requests across subscriptions of multiple profiles.
+// Each request is sent with the token of the next profile in round-robin
+// order and counted against subscription of that profile. Profiles which
+// exhausted their daily quota or got rate limited are skipped and
+// rate limited requests are retried with the next profile.
+type tokenPool struct {
+	mu      sync.Mutex
+	members []*poolMember
+	next    int
+	force   bool
+	log     logging.Logger
+}
+
+type poolMember struct {
+	profile string
+	sub     *auth.Subscription
+	quota   *quota
+	// limited is time until which member is skipped after 429 response.
+	limited time.Time
+}
+
+// pick returns next usable member not in tried.
+// Members over the quota are used only with --force.
+func (p *tokenPool) pick(tried map[int]bool) (int, *poolMember, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var overQuota []int
+	for i := range p.members {
+		idx := (p.next + i) % len(p.members)
+		m := p.members[idx]
+		if tried[idx] || now.Before(m.limited) {
+			continue
+		}
+		if m.quota.remaining(m.sub.RequestsToday) == 0 {
+			overQuota = append(overQuota, idx)
+			continue
+		}
+		return p.use(idx)
+	}
+	if len(overQuota) > 0 && p.force {
+		return p.use(overQuota[0])
+	}
+	if len(overQuota) > 0 {
+		return -1, nil, fmt.Errorf("%w: daily quota of all profiles in --profiles used, use --force to send anyway", ErrQuotaExceeded)
+	}
+	return -1, nil, errNoTokens
+}
+
+// use counts request against member at idx and moves rotation past it.
+func (p *tokenPool) use(idx int) (int, *poolMember, error) {
+	m := p.members[idx]
+	p.next = idx + 1
+	if err := m.quota.check(m.sub.RequestsToday, 1); err != nil {
+		return -1, nil, err
+	}
+	m.sub.RequestsToday++
+	if err := m.sub.Save(); err != nil {
+		return -1, nil, fmt.Errorf("failed to save subscription of profile %s: %w", m.profile, err)
+	}
+	return idx, m, nil
+}
+
+// limit marks member rate limited until Retry-After of the response.
+func (p *tokenPool) limit(m *poolMember, res *http.Response) {
+	cooldown := defaultTokenCooldown
+	if after, ok := retryAfter(res); ok && after > 0 {
+		cooldown = after
+	}
+	p.mu.Lock()
+	m.limited = time.Now().Add(cooldown)
+	p.mu.Unlock()
+}
+
+// reserve checks whether n more requests fit into remaining
+// daily quota of all profiles.
+func (p *tokenPool) reserve(n uint) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var remaining uint
+	for _, m := range p.members {
+		r := m.quota.remaining(m.sub.RequestsToday)
+		if r == math.MaxUint {
+			return nil
+		}
+		remaining += r
+	}
+	if n <= remaining || p.force {
+		return nil
+	}
+	return fmt.Errorf("%w: %d requests needed, %d daily requests remaining in --profiles, use --force to send anyway",
+		ErrQuotaExceeded, n, remaining)
+}
+
+// roundTrip sends request with token of the next profile and retries
+// it with other profiles while response is 429 Too Many Requests.
+func (p *tokenPool) roundTrip(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+	body, err := bufferBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tried = make(map[int]bool, len(p.members))
+		last  *http.Response
+	)
+	for len(tried) < len(p.members) {
+		idx, m, err := p.pick(tried)
+		if err != nil {
+			if last != nil {
+				return last, nil
+			}
+			return nil, err
+		}
+		tried[idx] = true
+		if last != nil {
+			last.Body.Close()
+		}
+
+		r := withBody(req.Clone(req.Context()), body)
+		r.Header.Set("Authorization", "Bearer "+m.sub.JWT)
+		p.log.Debug("request with token of profile",
+			slog.String("profile", m.profile),
+			slog.String("endpoint", req.URL.Path),
+		)
+
+		res, err := next.RoundTrip(r)
+		if err != nil || res.StatusCode != http.StatusTooManyRequests {
+			return res, err
+		}
+		p.limit(m, res)
+		p.log.Warn("profile rate limited, failing over to next profile",
+			slog.String("profile", m.profile),
+			slog.String("retry-after", res.Header.Get("Retry-After")),
+		)
+		last = res
+	}
+	return last, nil
+}
+
+// profileNames parses comma separated list of profile names,
+// profile listed more than once is used once in order of first occurrence.
+func profileNames(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
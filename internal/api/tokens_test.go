@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfileNames(t *testing.T) {
+	tests := []struct {
+		list  string
+		names []string
+	}{
+		{list: ""},
+		{list: " , ,"},
+		{list: "a", names: []string{"a"}},
+		{list: "b, a ,c", names: []string{"b", "a", "c"}},
+		{list: "b,a,b, a,c", names: []string{"b", "a", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.list, func(t *testing.T) {
+			if names := profileNames(tt.list); !reflect.DeepEqual(names, tt.names) {
+				t.Errorf("profileNames(%q) = %q, want %q", tt.list, names, tt.names)
+			}
+		})
+	}
+}
@@ -5,7 +5,19 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/cardano-community/koios-go-client/v4"
 	"github.com/happy-sdk/happy"
@@ -18,30 +30,20 @@ const categoryTransactions = "transactions"
 // https://api.koios.rest/#tag--Transactions
 func transactions(cmd *happy.Command, c *client) {
 	cmd.DescribeCategory(categoryTransactions, "Query blockchain transaction details")
-	cmd.AddSubCommand(cmdTransactionsUtxoInfo(c))
-	cmd.AddSubCommand(cmdTransactionsTxInfo(c))
-	cmd.AddSubCommand(cmdTransactionsTxMetadata(c))
-	cmd.AddSubCommand(cmdTransactionsTxMetalabels(c))
-	cmd.AddSubCommand(cmdTransactionsSubmittx(c))
-	cmd.AddSubCommand(cmdTransactionsTxStatus(c))
+	addSubCommand(cmd, cmdTransactionsUtxoInfo(c))
+	addSubCommand(cmd, cmdTransactionsTxInfo(c))
+	addSubCommand(cmd, cmdTransactionsTxCbor(c))
+	addSubCommand(cmd, cmdTransactionsTxUtxos(c))
+	addSubCommand(cmd, cmdTransactionsTxMetadata(c))
+	addSubCommand(cmd, cmdTransactionsTxMetalabels(c))
+	addSubCommand(cmd, cmdTransactionsSubmittx(c))
+	addSubCommand(cmd, cmdTransactionsTxStatus(c))
 }
 
 func cmdTransactionsUtxoInfo(c *client) *happy.Command {
-	cmd := happy.NewCommand("utxo_info",
-		happy.Option("description", "UTxO Info"),
-		happy.Option("category", categoryTransactions),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-		happy.Option("usage", "koios api utxo_info [_utxo_refs...] // max 50"),
-	).WithFlags(slices.Concat(pagingFlags, flagSlice(
-		varflag.BoolFunc("extended", false, "Controls whether or not certain optional fields supported by a given endpoint are populated as a part of the call"),
-	))...)
-
-	cmd.AddInfo("Array of Cardano UTxO references in the form \"hash#index\" with extended flag to toggle additional fields")
+	cmd := endpointUtxoInfo.command().WithFlags(slices.Concat(pagingFlags, flagSlice(fromFileFlag))...)
 
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#post-/utxo_info
-
     Example: koios-cli api utxo_info \
       f144a8264acf4bdfe2e1241170969c930d64ab6b0996a4a45237b623f1dd670e#0 \
       0b8ba3bed976fa4913f19adc9f6dd9063138db5b4dd29cecde369456b5155e94#0
@@ -52,80 +54,78 @@ func cmdTransactionsUtxoInfo(c *client) *happy.Command {
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-
-		var utxos []koios.UTxORef
-		for _, arg := range args.Args() {
-			utxos = append(utxos, koios.UTxORef(arg.String()))
-		}
-
-		res, err := c.koios().GetUTxOInfo(sess, utxos, args.Flag("extended").Var().Bool(), opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.batch(sess, args, endpointUtxoInfo, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetUTxOInfo(ctx, argsOf[koios.UTxORef](batch), args.Flag("extended").Var().Bool(), opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdTransactionsTxInfo(c *client) *happy.Command {
-	cmd := happy.NewCommand("tx_info",
-		happy.Option("description", "Transaction Information"),
-		happy.Option("category", categoryTransactions),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-		happy.Option("usage", "koios api tx_info [_tx_hashes...] // max 50"),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("Get detailed information about transaction(s)")
+	cmd := endpointTxInfo.command().WithFlags(slices.Concat(pagingFlags, flagSlice(fromFileFlag))...)
 
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#post-/tx_info
-
     Example: koios-cli api tx_info \
       f144a8264acf4bdfe2e1241170969c930d64ab6b0996a4a45237b623f1dd670e \
       0b8ba3bed976fa4913f19adc9f6dd9063138db5b4dd29cecde369456b5155e94
 
+    Example: koios-cli api tx_info --inputs --metadata --assets \
+      f144a8264acf4bdfe2e1241170969c930d64ab6b0996a4a45237b623f1dd670e
+
     Example: koios-cli api tx_info --page 1 --page-size 5 \
       f144a8264acf4bdfe2e1241170969c930d64ab6b0996a4a45237b623f1dd670e \
       0b8ba3bed976fa4913f19adc9f6dd9063138db5b4dd29cecde369456b5155e94
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
+		return c.batch(sess, args, endpointTxInfo, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.post(ctx, "/tx_info", endpointTxInfo.payload(args, "_tx_hashes", batch), opts)
+		})
+	})
 
-		var txs []koios.TxHash
-		for _, arg := range args.Args() {
-			txs = append(txs, koios.TxHash(arg.String()))
-		}
+	return cmd
+}
+
+func cmdTransactionsTxCbor(c *client) *happy.Command {
+	cmd := endpointTxCbor.command().WithFlags(slices.Concat(pagingFlags, flagSlice(fromFileFlag))...)
+
+	cmd.AddInfo(`
+    Example: koios-cli api tx_cbor \
+      f144a8264acf4bdfe2e1241170969c930d64ab6b0996a4a45237b623f1dd670e \
+      0b8ba3bed976fa4913f19adc9f6dd9063138db5b4dd29cecde369456b5155e94
+  `)
 
-		res, err := c.koios().GetTxInfo(sess, txs, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.batch(sess, args, endpointTxCbor, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.post(ctx, "/tx_cbor", map[string][]string{"_tx_hashes": batch}, opts)
+		})
 	})
 
 	return cmd
 }
 
-func cmdTransactionsTxMetadata(c *client) *happy.Command {
-	cmd := happy.NewCommand("tx_metadata",
-		happy.Option("description", "Transaction Metadata"),
-		happy.Option("category", categoryTransactions),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-		happy.Option("usage", "koios api tx_metadata [_tx_hashes...] // max 50"),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("Get metadata information (if any) for given transaction(s)")
+func cmdTransactionsTxUtxos(c *client) *happy.Command {
+	cmd := endpointTxUtxos.command().WithFlags(slices.Concat(pagingFlags, flagSlice(fromFileFlag))...)
 
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#post-/tx_metadata
+    Example: koios-cli api tx_utxos \
+      f144a8264acf4bdfe2e1241170969c930d64ab6b0996a4a45237b623f1dd670e
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.batch(sess, args, endpointTxUtxos, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.post(ctx, "/tx_utxos", map[string][]string{"_tx_hashes": batch}, opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdTransactionsTxMetadata(c *client) *happy.Command {
+	cmd := endpointTxMetadata.command().WithFlags(slices.Concat(pagingFlags, flagSlice(fromFileFlag))...)
 
+	cmd.AddInfo(`
     Example: koios-cli api tx_metadata \
       f144a8264acf4bdfe2e1241170969c930d64ab6b0996a4a45237b623f1dd670e \
       0b8ba3bed976fa4913f19adc9f6dd9063138db5b4dd29cecde369456b5155e94
@@ -136,90 +136,181 @@ func cmdTransactionsTxMetadata(c *client) *happy.Command {
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-
-		var txs []koios.TxHash
-		for _, arg := range args.Args() {
-			txs = append(txs, koios.TxHash(arg.String()))
-		}
-
-		res, err := c.koios().GetTxMetadata(sess, txs, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.batch(sess, args, endpointTxMetadata, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetTxMetadata(ctx, argsOf[koios.TxHash](batch), opts)
+		})
 	})
 
 	return cmd
 }
 
 func cmdTransactionsTxMetalabels(c *client) *happy.Command {
-	cmd := happy.NewCommand("tx_metalabels",
-		happy.Option("description", "Transaction Metadata Labels"),
-		happy.Option("category", categoryTransactions),
-		happy.Option("usage", "koios api tx_metalabels"),
-	).WithFlags(pagingFlags...)
-
-	cmd.AddInfo("Get a list of all transaction metalabels")
+	cmd := endpointTxMetalabels.command().WithFlags(pagingFlags...)
 
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#get-/tx_metalabels
-
     Example: koios-cli api tx_metalabels
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		return c.paginate(sess, args, func(ctx context.Context, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetTxMetaLabels(ctx, opts)
+		})
+	})
+
+	return cmd
+}
+
+func cmdTransactionsSubmittx(c *client) *happy.Command {
+	cmd := endpointSubmittx.command(
+		happy.Option("argn.max", 1),
+		happy.Option("usage", "koios api submittx [file|-]"),
+	).WithFlags(
+		varflag.UintFunc("wait", 0, "Wait until transaction has number of confirmations"),
+	)
+
+	cmd.AddInfo(`
+    Signed transaction is read from file or from stdin when file is - or not given.
+    It can be raw cbor, cbor hex or cardano-cli json envelope with cborHex field.
+    Transaction must decode as cbor before it is submitted, response data is the
+    transaction hash. With --wait tx_status is polled until transaction has
+    number of confirmations, interrupt (Ctrl-C) stops waiting.
+
+    Example: koios-cli api submittx tx.signed
+    Example: cardano-cli conway transaction sign ... --out-file /dev/stdout | koios-cli api submittx
+    Example: koios-cli api submittx --wait 3 tx.signed
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		tx, err := readTx(args.Arg(0).String())
+		if err != nil {
+			return categorize(errCodeUsage, err)
+		}
+		hash, err := txHash(tx)
+		if err != nil {
+			return usageErrorf("input is not valid transaction: %w", err)
+		}
+		sess.Log().Debug("submitting transaction", slog.String("tx_hash", hash), slog.Int("size", len(tx)))
+
 		opts, err := c.newRequestOpts(sess, args)
 		if err != nil {
 			return err
 		}
-
-		res, err := c.koios().GetTxMetaLabels(sess, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		res, err := c.koios().SubmitSignedTx(sess, koios.TxBodyJSON{CborHex: hex.EncodeToString(tx)}, opts)
+		if err := c.output(res, err); err != nil {
+			return err
+		}
+		if string(res.Data) != hash {
+			sess.Log().Warn("submitted transaction hash differs from hash of transaction body",
+				slog.String("tx_hash", string(res.Data)),
+				slog.String("body_hash", hash),
+			)
+		}
+		if confirmations := args.Flag("wait").Var().Uint(); confirmations > 0 {
+			return c.waitTx(sess, res.Data, uint64(confirmations))
+		}
+		return nil
 	})
 
 	return cmd
 }
 
-func cmdTransactionsSubmittx(c *client) *happy.Command {
-	return notimplCmd(categoryTransactions, "submittx")
+// readTx reads signed transaction from file or stdin when path is empty
+// or - as raw cbor, cbor hex or cardano-cli json envelope.
+func readTx(path string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path != "" && path != "-" {
+		data, err = os.ReadFile(path)
+	} else {
+		data, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction: %w", err)
+	}
+
+	text := bytes.TrimSpace(data)
+	if len(text) == 0 {
+		return nil, errors.New("transaction is empty")
+	}
+	if text[0] == '{' {
+		var envelope koios.TxBodyJSON
+		if err := json.Unmarshal(text, &envelope); err != nil {
+			return nil, fmt.Errorf("failed to decode transaction envelope: %w", err)
+		}
+		if strings.HasPrefix(envelope.Type, "Unwitnessed") {
+			return nil, fmt.Errorf("transaction of type %q is not signed", envelope.Type)
+		}
+		if envelope.CborHex == "" {
+			return nil, errors.New("transaction envelope has no cborHex")
+		}
+		data, err := hex.DecodeString(envelope.CborHex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode cborHex of transaction envelope: %w", err)
+		}
+		return data, nil
+	}
+	if tx, err := hex.DecodeString(string(text)); err == nil {
+		return tx, nil
+	}
+	return data, nil
 }
 
-func cmdTransactionsTxStatus(c *client) *happy.Command {
-	cmd := happy.NewCommand("tx_status",
-		happy.Option("description", "Transaction Status"),
-		happy.Option("category", categoryTransactions),
-		happy.Option("argn.min", 1),
-		happy.Option("argn.max", 50),
-		happy.Option("usage", "koios api tx_status [_tx_hashes...] // max 50"),
-	)
+// txStatusPollInterval is interval tx_status is polled at while waiting
+// for confirmations, blocks are produced about every 20 seconds.
+const txStatusPollInterval = 10 * time.Second
+
+// waitTx polls tx_status until transaction has number of confirmations.
+func (c *client) waitTx(sess *happy.Session, hash koios.TxHash, confirmations uint64) error {
+	ctx, stop := signal.NotifyContext(sess, os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(txStatusPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for transaction %s: %w", hash, ctx.Err())
+		case <-ticker.C:
+		}
+		opts, err := c.newRequestOpts(sess, nil)
+		if err != nil {
+			return err
+		}
+		res, err := c.koios().GetTxStatus(ctx, []koios.TxHash{hash}, opts)
+		if err != nil {
+			return requestErr(res, err)
+		}
+		var got uint64
+		if len(res.Data) > 0 {
+			got = res.Data[0].Confirmations
+		}
+		if got >= confirmations {
+			sess.Log().Ok("transaction confirmed", slog.String("tx_hash", string(hash)), slog.Uint64("confirmations", got))
+			return nil
+		}
+		sess.Log().Info("waiting for confirmations",
+			slog.String("tx_hash", string(hash)),
+			slog.Uint64("confirmations", got),
+			slog.Uint64("wait", confirmations),
+		)
+	}
+}
 
-	cmd.AddInfo("Get the number of block confirmations for a given transaction hash list")
+func cmdTransactionsTxStatus(c *client) *happy.Command {
+	cmd := endpointTxStatus.command().WithFlags(fromFileFlag)
 
 	cmd.AddInfo(`
-    Docs: https://api.koios.rest/#post-/tx_status
-
     Example: koios-cli api tx_status \
       f144a8264acf4bdfe2e1241170969c930d64ab6b0996a4a45237b623f1dd670e \
       0b8ba3bed976fa4913f19adc9f6dd9063138db5b4dd29cecde369456b5155e94
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		opts, err := c.newRequestOpts(sess, args)
-		if err != nil {
-			return err
-		}
-
-		var txs []koios.TxHash
-		for _, arg := range args.Args() {
-			txs = append(txs, koios.TxHash(arg.String()))
-		}
-
-		res, err := c.koios().GetTxStatus(sess, txs, opts)
-		apiOutput(c.noFormat, res, err)
-		return err
+		return c.batch(sess, args, endpointTxStatus, func(ctx context.Context, batch []string, opts *koios.RequestOptions) (any, error) {
+			return c.koios().GetTxStatus(ctx, argsOf[koios.TxHash](batch), opts)
+		})
 	})
 
 	return cmd
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"net/http"
+)
+
+// transport returns http transport used by koios client.
+// Requests are answered from response cache when possible and only
+// requests sent to the koios api are counted against the subscription,
+// each host tried when failing over to fallback hosts and each retry.
+func (c *client) transport() http.RoundTripper {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.MaxIdleConns = 100
+	base.MaxConnsPerHost = 100
+	base.MaxIdleConnsPerHost = 100
+
+	var rt http.RoundTripper = &countingTransport{c: c, next: base}
+	c.failover.next = rt
+	rt = c.failover
+	if c.retry != nil {
+		c.retry.next = rt
+		rt = c.retry
+	}
+	if c.cache != nil {
+		c.cache.next = rt
+		rt = c.cache
+	}
+	return rt
+}
+
+// countingTransport counts requests sent to the koios api, against
+// subscriptions of token pool when --profiles flag is used.
+type countingTransport struct {
+	c    *client
+	next http.RoundTripper
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.c.tokens != nil {
+		return t.c.tokens.roundTrip(req, t.next)
+	}
+	if err := t.c.countRequest(); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
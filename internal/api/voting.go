@@ -0,0 +1,398 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+
+	"github.com/happy-sdk/happy"
+)
+
+// Voter groups of the governance tally.
+const (
+	voterDRep = "DRep"
+	voterSPO  = "SPO"
+	voterCC   = "CC"
+)
+
+// Protocol parameter groups of DRep thresholds of parameter change.
+const (
+	ppNetworkGroup   = "network"
+	ppEconomicGroup  = "economic"
+	ppTechnicalGroup = "technical"
+	ppGovGroup       = "gov"
+)
+
+// parameterGroups maps protocol parameters, as named in parameter update of
+// proposal_description, to their group. Security parameters are voted by
+// SPOs too.
+var parameterGroups = map[string]struct {
+	group    string
+	security bool
+}{
+	"maxBlockBodySize":           {ppNetworkGroup, true},
+	"maxTxSize":                  {ppNetworkGroup, true},
+	"maxBlockHeaderSize":         {ppNetworkGroup, true},
+	"maxValueSize":               {ppNetworkGroup, true},
+	"maxTxExecutionUnits":        {ppNetworkGroup, false},
+	"maxBlockExecutionUnits":     {ppNetworkGroup, true},
+	"maxCollateralInputs":        {ppNetworkGroup, false},
+	"txFeePerByte":               {ppEconomicGroup, true},
+	"txFeeFixed":                 {ppEconomicGroup, true},
+	"stakeAddressDeposit":        {ppEconomicGroup, false},
+	"stakePoolDeposit":           {ppEconomicGroup, false},
+	"monetaryExpansion":          {ppEconomicGroup, false},
+	"treasuryCut":                {ppEconomicGroup, false},
+	"minPoolCost":                {ppEconomicGroup, false},
+	"coinsPerUTxOByte":           {ppEconomicGroup, true},
+	"executionUnitPrices":        {ppEconomicGroup, false},
+	"minFeeRefScriptCostPerByte": {ppEconomicGroup, true},
+	"poolPledgeInfluence":        {ppTechnicalGroup, false},
+	"poolRetireMaxEpoch":         {ppTechnicalGroup, false},
+	"stakePoolTargetNum":         {ppTechnicalGroup, false},
+	"costModels":                 {ppTechnicalGroup, false},
+	"collateralPercentage":       {ppTechnicalGroup, false},
+	"poolVotingThresholds":       {ppGovGroup, false},
+	"dRepVotingThresholds":       {ppGovGroup, false},
+	"committeeMinSize":           {ppGovGroup, false},
+	"committeeMaxTermLength":     {ppGovGroup, false},
+	"govActionLifetime":          {ppGovGroup, false},
+	"govActionDeposit":           {ppGovGroup, true},
+	"dRepDeposit":                {ppGovGroup, false},
+	"dRepActivity":               {ppGovGroup, false},
+}
+
+// votingSummary is record of proposal_voting_summary response.
+// Vote powers are in lovelace and percentages of yes and no votes
+// are stake-weighted as computed by the api.
+type votingSummary struct {
+	ProposalID   string   `json:"-"`
+	ProposalType string   `json:"proposal_type"`
+	EpochNo      uint     `json:"epoch_no"`
+	Parameters   []string `json:"-"` // changed by parameter change, see proposalParameters
+
+	DRepYesVotes           uint        `json:"drep_yes_votes_cast"`
+	DRepYesPower           json.Number `json:"drep_yes_vote_power"`
+	DRepYesPct             float64     `json:"drep_yes_pct"`
+	DRepNoVotes            uint        `json:"drep_no_votes_cast"`
+	DRepNoPower            json.Number `json:"drep_no_vote_power"`
+	DRepNoPct              float64     `json:"drep_no_pct"`
+	DRepAbstainVotes       uint        `json:"drep_abstain_votes_cast"`
+	DRepActiveAbstainPower json.Number `json:"drep_active_abstain_vote_power"`
+	DRepAlwaysAbstainPower json.Number `json:"drep_always_abstain_vote_power"`
+	PoolYesVotes           uint        `json:"pool_yes_votes_cast"`
+	PoolYesPower           json.Number `json:"pool_yes_vote_power"`
+	PoolYesPct             float64     `json:"pool_yes_pct"`
+	PoolNoVotes            uint        `json:"pool_no_votes_cast"`
+	PoolNoPower            json.Number `json:"pool_no_vote_power"`
+	PoolNoPct              float64     `json:"pool_no_pct"`
+	PoolAbstainVotes       uint        `json:"pool_abstain_votes_cast"`
+	PoolActiveAbstainPower json.Number `json:"pool_active_abstain_vote_power"`
+	PoolAlwaysAbstainPower json.Number `json:"pool_passive_always_abstain_vote_power"`
+	CommitteeYesVotes      uint        `json:"committee_yes_votes_cast"`
+	CommitteeYesPct        float64     `json:"committee_yes_pct"`
+	CommitteeNoVotes       uint        `json:"committee_no_votes_cast"`
+	CommitteeNoPct         float64     `json:"committee_no_pct"`
+	CommitteeAbstainVotes  uint        `json:"committee_abstain_votes_cast"`
+}
+
+// votingThresholds are DRep and SPO voting thresholds of epoch_params record.
+type votingThresholds struct {
+	DRepMotionNoConfidence   float64 `json:"dvt_motion_no_confidence"`
+	DRepCommitteeNormal      float64 `json:"dvt_committee_normal"`
+	DRepUpdateToConstitution float64 `json:"dvt_update_to_constitution"`
+	DRepHardForkInitiation   float64 `json:"dvt_hard_fork_initiation"`
+	DRepPPNetworkGroup       float64 `json:"dvt_p_p_network_group"`
+	DRepPPEconomicGroup      float64 `json:"dvt_p_p_economic_group"`
+	DRepPPTechnicalGroup     float64 `json:"dvt_p_p_technical_group"`
+	DRepPPGovGroup           float64 `json:"dvt_p_p_gov_group"`
+	DRepTreasuryWithdrawal   float64 `json:"dvt_treasury_withdrawal"`
+	PoolMotionNoConfidence   float64 `json:"pvt_motion_no_confidence"`
+	PoolCommitteeNormal      float64 `json:"pvt_committee_normal"`
+	PoolHardForkInitiation   float64 `json:"pvt_hard_fork_initiation"`
+	PoolPPSecurityGroup      float64 `json:"pvtpp_security_group"`
+}
+
+// voteTally is row of the governance tally of single voter group.
+// Threshold and passing are null when voter group votes on the proposal
+// but the proposal type is not ratified by the group, e.g. info actions,
+// or when groups of parameters changed by the proposal are unknown.
+type voteTally struct {
+	ProposalID   string   `json:"proposal_id"`
+	ProposalType string   `json:"proposal_type"`
+	EpochNo      uint     `json:"epoch_no"`
+	Voter        string   `json:"voter"`
+	Yes          uint     `json:"yes"`
+	No           uint     `json:"no"`
+	Abstain      uint     `json:"abstain"`
+	YesPower     string   `json:"yes_power"`
+	NoPower      string   `json:"no_power"`
+	AbstainPower string   `json:"abstain_power"`
+	YesPct       float64  `json:"yes_pct"`
+	NoPct        float64  `json:"no_pct"`
+	Threshold    *float64 `json:"threshold_pct"`
+	Passing      *bool    `json:"passing"`
+}
+
+// thresholds returns ratification thresholds of proposal type in percent
+// for each voter group voting on it. Threshold is nil when voter group
+// votes but the proposal is not ratified by it. Thresholds of parameter
+// change depend on parameters it changes.
+func (t votingThresholds) thresholds(proposalType string, parameters []string, quorum *float64) map[string]*float64 {
+	pct := func(v float64) *float64 {
+		v = roundPct(v * 100)
+		return &v
+	}
+	switch proposalType {
+	case "NoConfidence":
+		return map[string]*float64{
+			voterDRep: pct(t.DRepMotionNoConfidence),
+			voterSPO:  pct(t.PoolMotionNoConfidence),
+		}
+	case "NewCommittee":
+		return map[string]*float64{
+			voterDRep: pct(t.DRepCommitteeNormal),
+			voterSPO:  pct(t.PoolCommitteeNormal),
+		}
+	case "NewConstitution":
+		return map[string]*float64{
+			voterDRep: pct(t.DRepUpdateToConstitution),
+			voterCC:   quorum,
+		}
+	case "HardForkInitiation":
+		return map[string]*float64{
+			voterDRep: pct(t.DRepHardForkInitiation),
+			voterSPO:  pct(t.PoolHardForkInitiation),
+			voterCC:   quorum,
+		}
+	case "ParameterChange":
+		return t.parameterChangeThresholds(parameters, quorum)
+	case "TreasuryWithdrawals":
+		return map[string]*float64{
+			voterDRep: pct(t.DRepTreasuryWithdrawal),
+			voterCC:   quorum,
+		}
+	}
+	// info actions are voted by all groups but never ratified
+	return map[string]*float64{voterDRep: nil, voterSPO: nil, voterCC: nil}
+}
+
+// parameterChangeThresholds returns thresholds of change of parameters.
+// DRep threshold is the highest threshold of groups of the parameters and
+// SPOs vote only when security parameter is changed. When any parameter is
+// unknown, DRep and SPO thresholds are nil.
+func (t votingThresholds) parameterChangeThresholds(parameters []string, quorum *float64) map[string]*float64 {
+	groups := map[string]float64{
+		ppNetworkGroup:   t.DRepPPNetworkGroup,
+		ppEconomicGroup:  t.DRepPPEconomicGroup,
+		ppTechnicalGroup: t.DRepPPTechnicalGroup,
+		ppGovGroup:       t.DRepPPGovGroup,
+	}
+	var (
+		drep     float64
+		security bool
+	)
+	for _, name := range parameters {
+		p, ok := parameterGroups[name]
+		if !ok {
+			parameters = nil
+			break
+		}
+		drep = max(drep, groups[p.group])
+		security = security || p.security
+	}
+	if len(parameters) == 0 {
+		return map[string]*float64{voterDRep: nil, voterSPO: nil, voterCC: quorum}
+	}
+
+	drep = roundPct(drep * 100)
+	thresholds := map[string]*float64{voterDRep: &drep, voterCC: quorum}
+	if security {
+		spo := roundPct(t.PoolPPSecurityGroup * 100)
+		thresholds[voterSPO] = &spo
+	}
+	return thresholds
+}
+
+// committeeRatified reports whether proposal type needs approval of
+// constitutional committee.
+func committeeRatified(proposalType string) bool {
+	switch proposalType {
+	case "NewConstitution", "HardForkInitiation", "ParameterChange", "TreasuryWithdrawals":
+		return true
+	}
+	return false
+}
+
+// votingTally returns tally rows of voting summaries. Thresholds are read
+// from epoch_params of each summary epoch and committee quorum from
+// committee_info when any of the proposals needs committee approval.
+func (c *client) votingTally(sess *happy.Session, args happy.Args, summaries []votingSummary) ([]voteTally, error) {
+	var (
+		tally  []voteTally
+		params = make(map[uint]votingThresholds)
+		quorum *float64
+	)
+	for _, s := range summaries {
+		t, ok := params[s.EpochNo]
+		if !ok {
+			var err error
+			if t, err = c.votingThresholds(sess, args, s.EpochNo); err != nil {
+				return nil, err
+			}
+			params[s.EpochNo] = t
+		}
+		if quorum == nil && committeeRatified(s.ProposalType) {
+			q, err := c.committeeQuorum(sess, args)
+			if err != nil {
+				return nil, err
+			}
+			quorum = &q
+		}
+		if s.ProposalType == "ParameterChange" {
+			var err error
+			if s.Parameters, err = c.proposalParameters(sess, args, s.ProposalID); err != nil {
+				return nil, err
+			}
+		}
+		thresholds := t.thresholds(s.ProposalType, s.Parameters, quorum)
+
+		rows := []voteTally{
+			{
+				Voter: voterDRep, Yes: s.DRepYesVotes, No: s.DRepNoVotes, Abstain: s.DRepAbstainVotes,
+				YesPower: s.DRepYesPower.String(), NoPower: s.DRepNoPower.String(),
+				AbstainPower: sumLovelace(s.DRepActiveAbstainPower, s.DRepAlwaysAbstainPower),
+				YesPct:       s.DRepYesPct, NoPct: s.DRepNoPct,
+			},
+			{
+				Voter: voterSPO, Yes: s.PoolYesVotes, No: s.PoolNoVotes, Abstain: s.PoolAbstainVotes,
+				YesPower: s.PoolYesPower.String(), NoPower: s.PoolNoPower.String(),
+				AbstainPower: sumLovelace(s.PoolActiveAbstainPower, s.PoolAlwaysAbstainPower),
+				YesPct:       s.PoolYesPct, NoPct: s.PoolNoPct,
+			},
+			{
+				Voter: voterCC, Yes: s.CommitteeYesVotes, No: s.CommitteeNoVotes, Abstain: s.CommitteeAbstainVotes,
+				YesPct: s.CommitteeYesPct, NoPct: s.CommitteeNoPct,
+			},
+		}
+		for _, row := range rows {
+			threshold, votes := thresholds[row.Voter]
+			if !votes {
+				continue
+			}
+			row.ProposalID, row.ProposalType, row.EpochNo = s.ProposalID, s.ProposalType, s.EpochNo
+			if threshold != nil {
+				row.Threshold = threshold
+				passing := row.YesPct >= *threshold
+				row.Passing = &passing
+			}
+			tally = append(tally, row)
+		}
+	}
+	return tally, nil
+}
+
+// votingThresholds requests voting thresholds of epoch.
+func (c *client) votingThresholds(sess *happy.Session, args happy.Args, epoch uint) (votingThresholds, error) {
+	var t votingThresholds
+	opts, err := c.newRequestOpts(sess, args)
+	if err != nil {
+		return t, err
+	}
+	opts.QuerySet("_epoch_no", strconv.FormatUint(uint64(epoch), 10))
+	res, err := c.get(sess, "/epoch_params", opts)
+	if err != nil {
+		return t, requestErr(res, err)
+	}
+	if len(res.Data) == 0 {
+		return t, requestErr(res, fmt.Errorf("no parameters of epoch %d", epoch))
+	}
+	return t, json.Unmarshal(res.Data[0], &t)
+}
+
+// proposalParameters requests names of protocol parameters changed by
+// parameter change proposal. Names are nil when proposal_description
+// has no parameter update.
+func (c *client) proposalParameters(sess *happy.Session, args happy.Args, id string) ([]string, error) {
+	opts, err := c.newRequestOpts(sess, args)
+	if err != nil {
+		return nil, err
+	}
+	opts.QuerySet("proposal_id", "eq."+id)
+	opts.QuerySet("select", "proposal_description")
+	res, err := c.get(sess, "/proposal_list", opts)
+	if err != nil {
+		return nil, requestErr(res, err)
+	}
+	if len(res.Data) == 0 {
+		return nil, requestErr(res, fmt.Errorf("no proposal %s", id))
+	}
+	// description is governance action as {"tag": "ParameterChange",
+	// "contents": [previous action, parameter update, policy hash]}
+	var proposal struct {
+		Description struct {
+			Contents []json.RawMessage `json:"contents"`
+		} `json:"proposal_description"`
+	}
+	if err := json.Unmarshal(res.Data[0], &proposal); err != nil {
+		return nil, err
+	}
+	var update map[string]json.RawMessage
+	if len(proposal.Description.Contents) < 2 || json.Unmarshal(proposal.Description.Contents[1], &update) != nil {
+		return nil, nil
+	}
+	var names []string
+	for name := range update {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// committeeQuorum requests quorum of current constitutional committee in percent.
+func (c *client) committeeQuorum(sess *happy.Session, args happy.Args) (float64, error) {
+	opts, err := c.newRequestOpts(sess, args)
+	if err != nil {
+		return 0, err
+	}
+	res, err := c.get(sess, "/committee_info", opts)
+	if err != nil {
+		return 0, requestErr(res, err)
+	}
+	var info struct {
+		Numerator   float64 `json:"quorum_numerator"`
+		Denominator float64 `json:"quorum_denominator"`
+	}
+	if len(res.Data) > 0 {
+		if err := json.Unmarshal(res.Data[0], &info); err != nil {
+			return 0, err
+		}
+	}
+	if info.Denominator == 0 {
+		return 0, requestErr(res, fmt.Errorf("no quorum of constitutional committee"))
+	}
+	return roundPct(info.Numerator / info.Denominator * 100), nil
+}
+
+// roundPct rounds percentage to two decimals.
+func roundPct(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// sumLovelace returns sum of lovelace amounts, empty amounts are zero.
+func sumLovelace(amounts ...json.Number) string {
+	sum := new(big.Int)
+	for _, amount := range amounts {
+		if v, ok := new(big.Int).SetString(amount.String(), 10); ok {
+			sum.Add(sum, v)
+		}
+	}
+	return sum.String()
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package api
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParameterChangeThresholds(t *testing.T) {
+	params := votingThresholds{
+		DRepPPNetworkGroup:   0.67,
+		DRepPPEconomicGroup:  0.6,
+		DRepPPTechnicalGroup: 0.5,
+		DRepPPGovGroup:       0.75,
+		PoolPPSecurityGroup:  0.51,
+	}
+	quorum := 66.67
+	tests := []struct {
+		name       string
+		parameters []string
+		want       map[string]string
+	}{
+		{
+			name:       "security parameter",
+			parameters: []string{"maxTxSize"},
+			want:       map[string]string{voterDRep: "67", voterSPO: "51", voterCC: "66.67"},
+		},
+		{
+			name:       "non security parameters",
+			parameters: []string{"costModels", "stakePoolDeposit"},
+			want:       map[string]string{voterDRep: "60", voterCC: "66.67"},
+		},
+		{
+			name:       "highest group threshold",
+			parameters: []string{"collateralPercentage", "dRepActivity", "txFeeFixed"},
+			want:       map[string]string{voterDRep: "75", voterSPO: "51", voterCC: "66.67"},
+		},
+		{
+			name:       "unknown parameter",
+			parameters: []string{"maxTxSize", "unknownParameter"},
+			want:       map[string]string{voterDRep: "<nil>", voterSPO: "<nil>", voterCC: "66.67"},
+		},
+		{
+			name: "no parameters",
+			want: map[string]string{voterDRep: "<nil>", voterSPO: "<nil>", voterCC: "66.67"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := params.thresholds("ParameterChange", tt.parameters, &quorum)
+			if len(got) != len(tt.want) {
+				t.Errorf("thresholds() has voters %v, want %v", got, tt.want)
+			}
+			for voter, want := range tt.want {
+				threshold, ok := got[voter]
+				if !ok {
+					t.Errorf("thresholds() has no %s threshold", voter)
+					continue
+				}
+				s := "<nil>"
+				if threshold != nil {
+					s = fmt.Sprint(*threshold)
+				}
+				if s != want {
+					t.Errorf("thresholds() of %s = %s, want %s", voter, s, want)
+				}
+			}
+		})
+	}
+}
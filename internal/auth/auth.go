@@ -5,10 +5,9 @@
 package auth
 
 import (
-	"bytes"
-	"encoding/gob"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -19,6 +18,7 @@ import (
 	"github.com/cardano-community/koios-go-client/v4"
 	"github.com/happy-sdk/happy"
 	"github.com/happy-sdk/happy/pkg/strings/textfmt"
+	"github.com/happy-sdk/happy/pkg/vars/varflag"
 	"github.com/happy-sdk/happy/sdk/cli"
 )
 
@@ -42,19 +42,53 @@ func Command() *happy.Command {
 
     Example: koios-cli --profile <project-id> api tip
 
+    Profile can be also selected with ` + ProfileEnv + ` environment variable
+    or set as default profile, token with ` + AuthEnv + ` environment variable.
+
+    Example: koios-cli auth use <project-id>
+
     To see your current usage add --stats flag to the api command. e.g.
 
     Example: koios-cli --profile <project-id> api --stats tip
 
+    Request history of the profile and forecast of the daily quota
+    is shown with usage command.
+
+    Example: koios-cli auth usage <project-id>
+
+    Tokens have expiration date, warning is shown few days before token
+    expires. Expired token can be replaced keeping the profile history.
+
+    Example: koios-cli auth renew <project-id> <jwt-token>
+
     If you want to use Koios APi with your token without saving it to disk,
     you can use --auth flag with the token.
 
     Example: koios-cli api --auth <jwt-token> tip
+
+    Profiles can be stored encrypted with passphrase, which is asked when
+    profile is used or read from ` + PassphraseEnv + ` environment variable.
+
+    Example: koios-cli auth add --encrypt <jwt-token>
+    Example: koios-cli auth encrypt <project-id>
+
+    Profiles are stored as versioned json documents and can be moved
+    between machines with export and import.
+
+    Example: koios-cli auth export <project-id> profile.json
+    Example: koios-cli auth import profile.json
   `)
 
 	cmd.AddSubCommand(cmdAuthAdd())
 	cmd.AddSubCommand(cmdAuthRemove())
 	cmd.AddSubCommand(cmdList())
+	cmd.AddSubCommand(cmdAuthEncrypt())
+	cmd.AddSubCommand(cmdAuthDecrypt())
+	cmd.AddSubCommand(cmdAuthExport())
+	cmd.AddSubCommand(cmdAuthImport())
+	cmd.AddSubCommand(cmdAuthUsage())
+	cmd.AddSubCommand(cmdAuthRenew())
+	cmd.AddSubCommand(cmdAuthUse())
 	return cmd
 }
 
@@ -64,6 +98,8 @@ func cmdAuthAdd() *happy.Command {
 		happy.Option("argn.min", 1),
 		happy.Option("argn.max", 1),
 		happy.Option("usage", "koios auth add-token <token>"),
+	).WithFlags(
+		varflag.BoolFunc("encrypt", false, "Encrypt profile with passphrase"),
 	)
 
 	cmd.AddInfo("Add a new API token to your subscription")
@@ -73,6 +109,11 @@ func cmdAuthAdd() *happy.Command {
     If same project ID already exists, it will ask before overwriting the existing profile.
 
     Example: koios-cli auth add eyJhbGciOiJ...-JGHedpgsQVsI
+
+    With --encrypt flag the profile is encrypted with passphrase, which is
+    asked when profile is used or read from ` + PassphraseEnv + ` environment variable.
+
+    Example: koios-cli auth add --encrypt eyJhbGciOiJ...-JGHedpgsQVsI
   `)
 
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
@@ -126,8 +167,22 @@ func cmdAuthAdd() *happy.Command {
 			return fmt.Errorf("failed to create profile directory: %w", err)
 		}
 
+		subscription := &Subscription{
+			path:          koiosAuthFile,
+			JWT:           token,
+			RequestsToday: 0,
+		}
+
 		sess.Log().Notice("token will be saved", slog.String("path", koiosAuthFile))
-		if !cli.AskForConfirmation(`
+		if args.Flag("encrypt").Var().Bool() {
+			passphrase, err := readPassphrase("Enter passphrase to encrypt profile", true)
+			if err != nil {
+				return err
+			}
+			if err := subscription.Encrypt(passphrase); err != nil {
+				return fmt.Errorf("failed to encrypt subscription: %w", err)
+			}
+		} else if !cli.AskForConfirmation(`
       The token will be stored in unencrypted form, which is a security risk similar
       to keeping tokens in .env files. If you opt to save, it'll be located at:
       ` + koiosAuthFile + `
@@ -136,16 +191,12 @@ func cmdAuthAdd() *happy.Command {
       e.g.
         koios-cli --profile "` + authInfo.ProjID + `" api tip
 
+      To store the token encrypted use --encrypt flag.
+
       Do you wish to save the token?`) {
 			return errors.New("cancelled by user")
 		}
 
-		subscription := &Subscription{
-			path:          koiosAuthFile,
-			JWT:           token,
-			RequestsToday: 0,
-		}
-
 		if err := subscription.Save(); err != nil {
 			return fmt.Errorf("failed to save subscription: %w", err)
 		}
@@ -200,6 +251,12 @@ func cmdAuthRemove() *happy.Command {
 		if err := os.Remove(koiosAuthFile); err != nil {
 			return fmt.Errorf("failed to remove profile: %w", err)
 		}
+		if name, err := DefaultProfile(sess); err == nil && name == strings.TrimSuffix(profileName, "-devel") {
+			if err := setDefaultProfile(sess, ""); err != nil {
+				return err
+			}
+			sess.Log().Notice("default profile unset", slog.String("profile", profileName))
+		}
 
 		sess.Log().Ok("profile removed", slog.String("profile", profileName))
 		return nil
@@ -215,7 +272,7 @@ func cmdList() *happy.Command {
 
 	cmd.AddInfo("List all profiles and their subscription tokens")
 	cmd.Do(func(sess *happy.Session, args happy.Args) error {
-		configRootDir := filepath.Dir(sess.Get("app.fs.path.config").String())
+		configRootDir := profilesDir(sess)
 		files, err := os.ReadDir(configRootDir)
 		if err != nil {
 			return fmt.Errorf("failed to read config directory: %w", err)
@@ -230,7 +287,14 @@ func cmdList() *happy.Command {
 			Title:      "Profiles",
 			WithHeader: true,
 		}
-		profiles.AddRow("Profile", "Tier", "Expires", "Requests Today")
+		profiles.AddRow("Profile", "Tier", "Expires", "Status", "Requests Today", "Active")
+		active, source := activeProfileName(sess, args)
+		activeMark := func(name string) string {
+			if name == active {
+				return "* (" + source + ")"
+			}
+			return ""
+		}
 
 		for _, file := range files {
 			if !file.IsDir() {
@@ -241,12 +305,19 @@ func cmdList() *happy.Command {
 				continue
 			}
 
+			profileName := file.Name()
+			if encrypted, err := isEncryptedFile(koiosAuthFile); err != nil {
+				return err
+			} else if encrypted && os.Getenv(PassphraseEnv) == "" {
+				profiles.AddRow(profileName, "encrypted", "-", "-", "-", activeMark(profileName))
+				continue
+			}
+
 			sub, err := LoadSubscriptionFile(koiosAuthFile)
 			if err != nil {
 				return err
 			}
 
-			profileName := file.Name()
 			listitem := subscriptionListItem{
 				Name: profileName,
 			}
@@ -260,7 +331,7 @@ func cmdList() *happy.Command {
 			listitem.Expires = authInfo.Expires.String()
 			listitem.Tier = authInfo.Tier.String()
 
-			profiles.AddRow(profileName, listitem.Tier, listitem.Expires, fmt.Sprint(sub.RequestsToday))
+			profiles.AddRow(profileName, listitem.Tier, listitem.Expires, expiryStatus(authInfo, DefaultExpiryWarnDays), fmt.Sprint(sub.RequestsToday), activeMark(profileName))
 		}
 		fmt.Println(profiles.String())
 		return nil
@@ -279,10 +350,12 @@ type subscriptionListItem struct {
 
 type Subscription struct {
 	path          string
-	JWT           string
-	RequestsToday uint
-	Date          string
-	History       []RequestHistoryEntry
+	key           []byte
+	salt          []byte
+	JWT           string                `json:"jwt"`
+	RequestsToday uint                  `json:"requests_today"`
+	Date          string                `json:"date"`
+	History       []RequestHistoryEntry `json:"history"`
 }
 
 func (s *Subscription) Save() error {
@@ -297,12 +370,35 @@ func (s *Subscription) Save() error {
 		s.Date = today
 	}
 
-	var dest bytes.Buffer
-	enc := gob.NewEncoder(&dest)
-	if err := enc.Encode(s); err != nil {
+	data, err := s.marshal()
+	if err != nil {
 		return err
 	}
-	return os.WriteFile(s.path, dest.Bytes(), 0600)
+	return os.WriteFile(s.path, data, 0600)
+}
+
+// Encrypted reports whether subscription is stored encrypted.
+func (s *Subscription) Encrypted() bool {
+	return s.key != nil
+}
+
+// Encrypt sets passphrase used to encrypt subscription when it is saved.
+func (s *Subscription) Encrypt(passphrase []byte) error {
+	salt, err := newSalt()
+	if err != nil {
+		return err
+	}
+	key, err := deriveKey(passphrase, salt, defaultScryptParams)
+	if err != nil {
+		return err
+	}
+	s.key, s.salt = key, salt
+	return nil
+}
+
+// Decrypt removes passphrase so that subscription is saved unencrypted.
+func (s *Subscription) Decrypt() {
+	s.key, s.salt = nil, nil
 }
 
 func LoadSubscription(sess *happy.Session) (*Subscription, error) {
@@ -324,13 +420,18 @@ func LoadSubscriptionFile(koiosAuthFile string) (*Subscription, error) {
 		return nil, fmt.Errorf("failed to read auth token file: %w", err)
 	}
 
-	sub := &Subscription{}
-	dec := gob.NewDecoder(bytes.NewReader(data))
-	if err := dec.Decode(sub); err != nil {
-		return nil, fmt.Errorf("failed to decode auth token file: %w", err)
+	profileName := filepath.Base(filepath.Dir(koiosAuthFile))
+	sub, upgraded, err := decodeSubscription(data, fmt.Sprintf("Enter passphrase for profile %s", profileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load profile %s: %w", profileName, err)
 	}
 	sub.path = koiosAuthFile
 	sub.update()
+	if upgraded {
+		if err := sub.Save(); err != nil {
+			return nil, fmt.Errorf("failed to upgrade auth token file: %w", err)
+		}
+	}
 	return sub, nil
 }
 
@@ -352,6 +453,255 @@ func (s *Subscription) update() {
 }
 
 type RequestHistoryEntry struct {
-	Date     string
-	Requests uint
+	Date     string `json:"date"`
+	Requests uint   `json:"requests"`
+}
+
+func cmdAuthEncrypt() *happy.Command {
+	cmd := happy.NewCommand("encrypt",
+		happy.Option("description", "Encrypt existing profile with passphrase"),
+		happy.Option("argn.min", 1),
+		happy.Option("argn.max", 1),
+		happy.Option("usage", "koios auth encrypt <profile-name>"),
+	)
+
+	cmd.AddInfo("Encrypt subscription token of existing profile with passphrase")
+	cmd.AddInfo(`
+    Passphrase is asked when profile is used or read from ` + PassphraseEnv + `
+    environment variable.
+
+    Example: koios-cli auth encrypt my-project
+    Example: ` + PassphraseEnv + `=... koios-cli --profile my-project api tip
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		profileName, koiosAuthFile, err := profileFile(sess, args.Arg(0).String())
+		if err != nil {
+			return err
+		}
+		sub, err := LoadSubscriptionFile(koiosAuthFile)
+		if err != nil {
+			return err
+		}
+		if sub.Encrypted() {
+			return fmt.Errorf("profile %s is already encrypted", profileName)
+		}
+
+		passphrase, err := readPassphrase("Enter passphrase to encrypt profile", true)
+		if err != nil {
+			return err
+		}
+		if err := sub.Encrypt(passphrase); err != nil {
+			return fmt.Errorf("failed to encrypt subscription: %w", err)
+		}
+		if err := sub.Save(); err != nil {
+			return fmt.Errorf("failed to save subscription: %w", err)
+		}
+
+		sess.Log().Ok("profile encrypted", slog.String("profile", profileName))
+		return nil
+	})
+
+	return cmd
+}
+
+func cmdAuthDecrypt() *happy.Command {
+	cmd := happy.NewCommand("decrypt",
+		happy.Option("description", "Decrypt encrypted profile and store it without passphrase"),
+		happy.Option("argn.min", 1),
+		happy.Option("argn.max", 1),
+		happy.Option("usage", "koios auth decrypt <profile-name>"),
+	)
+
+	cmd.AddInfo("Decrypt subscription token of encrypted profile")
+	cmd.AddInfo(`
+    Example: koios-cli auth decrypt my-project
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		profileName, koiosAuthFile, err := profileFile(sess, args.Arg(0).String())
+		if err != nil {
+			return err
+		}
+		sub, err := LoadSubscriptionFile(koiosAuthFile)
+		if err != nil {
+			return err
+		}
+		if !sub.Encrypted() {
+			return fmt.Errorf("profile %s is not encrypted", profileName)
+		}
+
+		if !cli.AskForConfirmation(`
+      The token will be stored in unencrypted form, which is a security risk similar
+      to keeping tokens in .env files.
+
+      Do you wish to decrypt the profile?`) {
+			return errors.New("cancelled by user")
+		}
+
+		sub.Decrypt()
+		if err := sub.Save(); err != nil {
+			return fmt.Errorf("failed to save subscription: %w", err)
+		}
+
+		sess.Log().Ok("profile decrypted", slog.String("profile", profileName))
+		return nil
+	})
+
+	return cmd
+}
+
+func cmdAuthExport() *happy.Command {
+	cmd := happy.NewCommand("export",
+		happy.Option("description", "Export profile to file or stdout"),
+		happy.Option("argn.min", 1),
+		happy.Option("argn.max", 2),
+		happy.Option("usage", "koios auth export <profile-name> [file]"),
+	)
+
+	cmd.AddInfo("Export profile as json document which can be imported on other machine")
+	cmd.AddInfo(`
+    Encrypted profiles are exported encrypted with the same passphrase.
+
+    Example: koios-cli auth export my-project my-project.json
+    Example: koios-cli auth export my-project > my-project.json
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		profileName, koiosAuthFile, err := profileFile(sess, args.Arg(0).String())
+		if err != nil {
+			return err
+		}
+		sub, err := LoadSubscriptionFile(koiosAuthFile)
+		if err != nil {
+			return err
+		}
+		data, err := sub.marshal()
+		if err != nil {
+			return err
+		}
+
+		if args.Argn() < 2 {
+			fmt.Println(string(data))
+			return nil
+		}
+		dest := args.Arg(1).String()
+		if err := os.WriteFile(dest, append(data, '\n'), 0600); err != nil {
+			return fmt.Errorf("failed to export profile: %w", err)
+		}
+		sess.Log().Ok("profile exported", slog.String("profile", profileName), slog.String("path", dest))
+		return nil
+	})
+
+	return cmd
+}
+
+func cmdAuthImport() *happy.Command {
+	cmd := happy.NewCommand("import",
+		happy.Option("description", "Import profile exported with auth export"),
+		happy.Option("argn.min", 1),
+		happy.Option("argn.max", 1),
+		happy.Option("usage", "koios auth import <file|->"),
+	)
+
+	cmd.AddInfo("Import profile from file or from stdin with -")
+	cmd.AddInfo(`
+    Profile is created with name of project ID read from the JWT Token.
+    Profile files of older schema versions are upgraded on import.
+
+    Example: koios-cli auth import my-project.json
+    Example: ` + PassphraseEnv + `=... koios-cli auth import - < my-project.json
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		var (
+			data []byte
+			err  error
+		)
+		if src := args.Arg(0).String(); src == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(src)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read profile: %w", err)
+		}
+
+		sub, _, err := decodeSubscription(data, "Enter passphrase of imported profile")
+		if err != nil {
+			return fmt.Errorf("failed to import profile: %w", err)
+		}
+		authInfo, err := koios.GetTokenAuthInfo(sub.JWT)
+		if err != nil {
+			return err
+		}
+
+		profileName := authInfo.ProjID
+		if sess.Get("app.devel").Bool() {
+			profileName += "-devel"
+		}
+		configDir := filepath.Join(profilesDir(sess), profileName)
+		koiosAuthFile := filepath.Join(configDir, "koios.subscription")
+
+		if _, err := os.Stat(koiosAuthFile); err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to check auth token file: %w", err)
+			}
+		} else if !cli.AskForConfirmation(fmt.Sprintf("Profile (%s) already exists. Do you want to overwrite it?", profileName)) {
+			return fmt.Errorf("profile %s already exists, skipping", profileName)
+		}
+		if err := os.MkdirAll(configDir, 0700); err != nil {
+			return fmt.Errorf("failed to create profile directory: %w", err)
+		}
+
+		sub.path = koiosAuthFile
+		if err := sub.Save(); err != nil {
+			return fmt.Errorf("failed to save subscription: %w", err)
+		}
+		sess.Log().Ok("profile imported",
+			slog.String("profile", profileName),
+			slog.Bool("encrypted", sub.Encrypted()),
+			slog.String("path", koiosAuthFile),
+		)
+		return nil
+	})
+
+	return cmd
+}
+
+// profileFile returns profile name and path to subscription file of the profile.
+func profileFile(sess *happy.Session, name string) (string, string, error) {
+	profileName := strings.TrimSpace(name)
+	if len(profileName) == 0 {
+		return "", "", errors.New("profile name is required")
+	}
+	if sess.Get("app.devel").Bool() {
+		profileName += "-devel"
+	}
+
+	koiosAuthFile := filepath.Join(profilesDir(sess), profileName, "koios.subscription")
+	if _, err := os.Stat(koiosAuthFile); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", "", fmt.Errorf("profile %s does not exist", profileName)
+		}
+		return "", "", fmt.Errorf("failed to check auth token file: %w", err)
+	}
+	return profileName, koiosAuthFile, nil
+}
+
+// profilesDir returns directory containing all profiles.
+func profilesDir(sess *happy.Session) string {
+	if sess.Get("app.profile.name").String() == "public" {
+		return filepath.Join(sess.Get("app.fs.path.config").String(), "profiles")
+	}
+	return filepath.Dir(sess.Get("app.fs.path.config").String())
+}
+
+// isEncryptedFile reports whether subscription file is encrypted.
+func isEncryptedFile(koiosAuthFile string) (bool, error) {
+	data, err := os.ReadFile(koiosAuthFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read auth token file: %w", err)
+	}
+	return isEncryptedDocument(data), nil
 }
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package auth
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+const (
+	// PassphraseEnv is environment variable used as passphrase
+	// of encrypted profiles instead of interactive prompt.
+	PassphraseEnv = "KOIOS_PASSPHRASE"
+
+	kdfScrypt       = "scrypt"
+	cipherAES256GCM = "aes-256-gcm"
+	saltSize        = 16
+)
+
+var ErrInvalidPassphrase = errors.New("invalid passphrase")
+
+// scryptParams are cost parameters of scrypt key derivation.
+type scryptParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+// defaultScryptParams are used to derive key of newly sealed documents.
+var defaultScryptParams = scryptParams{N: 1 << 15, R: 8, P: 1}
+
+// encryption describes how encrypted subscription document was sealed.
+type encryption struct {
+	KDF       string       `json:"kdf"`
+	KDFParams scryptParams `json:"kdf_params"`
+	Cipher    string       `json:"cipher"`
+	Salt      []byte       `json:"salt"`
+	Nonce     []byte       `json:"nonce"`
+}
+
+// additionalData returns header of the document authenticated together
+// with the ciphertext, so that schema version and parameters of key
+// derivation can not be changed without detection.
+func (e *encryption) additionalData(version int) ([]byte, error) {
+	return json.Marshal(struct {
+		Version   int          `json:"version"`
+		KDF       string       `json:"kdf"`
+		KDFParams scryptParams `json:"kdf_params"`
+		Cipher    string       `json:"cipher"`
+		Salt      []byte       `json:"salt"`
+	}{version, e.KDF, e.KDFParams, e.Cipher, e.Salt})
+}
+
+// deriveKey derives AES-256 key from passphrase with scrypt.
+func deriveKey(passphrase, salt []byte, params scryptParams) ([]byte, error) {
+	return scrypt.Key(passphrase, salt, params.N, params.R, params.P, 32)
+}
+
+func newSalt() ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+// seal encrypts plaintext of schema version with AES-GCM using fresh nonce,
+// key is derived with defaultScryptParams.
+func seal(key, salt, plaintext []byte, version int) (*encryption, []byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	enc := &encryption{
+		KDF:       kdfScrypt,
+		KDFParams: defaultScryptParams,
+		Cipher:    cipherAES256GCM,
+		Salt:      salt,
+		Nonce:     nonce,
+	}
+	ad, err := enc.additionalData(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	return enc, gcm.Seal(nil, nonce, plaintext, ad), nil
+}
+
+// open decrypts ciphertext of schema version sealed with seal. It returns
+// plaintext together with derived key so that document can be sealed again.
+func open(enc *encryption, ciphertext, passphrase []byte, version int) (plaintext, key []byte, err error) {
+	if enc.KDF != kdfScrypt || enc.Cipher != cipherAES256GCM {
+		return nil, nil, fmt.Errorf("unsupported encryption %s/%s", enc.KDF, enc.Cipher)
+	}
+	ad, err := enc.additionalData(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if key, err = deriveKey(passphrase, enc.Salt, enc.KDFParams); err != nil {
+		return nil, nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(enc.Nonce) != gcm.NonceSize() {
+		return nil, nil, errors.New("invalid encryption nonce")
+	}
+	if plaintext, err = gcm.Open(nil, enc.Nonce, ciphertext, ad); err != nil {
+		return nil, nil, ErrInvalidPassphrase
+	}
+	return plaintext, key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// readPassphrase returns passphrase from PassphraseEnv or prompts for it
+// when stdin is terminal. With confirm passphrase is asked twice.
+func readPassphrase(prompt string, confirm bool) ([]byte, error) {
+	if p := os.Getenv(PassphraseEnv); p != "" {
+		return []byte(p), nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, fmt.Errorf("passphrase required, set %s or run from terminal", PassphraseEnv)
+	}
+
+	fmt.Fprint(os.Stderr, prompt+": ")
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase can not be empty")
+	}
+	if !confirm {
+		return passphrase, nil
+	}
+
+	fmt.Fprint(os.Stderr, "Repeat passphrase: ")
+	repeated, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if !bytes.Equal(passphrase, repeated) {
+		return nil, errors.New("passphrases do not match")
+	}
+	return passphrase, nil
+}
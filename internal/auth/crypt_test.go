@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	passphrase := []byte("correct horse battery staple")
+	plaintext := []byte(`{"version":1,"jwt":"token"}`)
+	salt, err := newSalt()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := deriveKey(passphrase, salt, defaultScryptParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		passphrase []byte
+		version    int
+		tamper     func(enc *encryption, ciphertext []byte)
+		err        error
+	}{
+		{name: "round trip", passphrase: passphrase, version: SchemaVersion},
+		{name: "wrong passphrase", passphrase: []byte("wrong"), version: SchemaVersion, err: ErrInvalidPassphrase},
+		{name: "tampered version", passphrase: passphrase, version: SchemaVersion + 1, err: ErrInvalidPassphrase},
+		{
+			name:       "tampered kdf params",
+			passphrase: passphrase,
+			version:    SchemaVersion,
+			tamper:     func(enc *encryption, _ []byte) { enc.KDFParams.N = 1 << 14 },
+			err:        ErrInvalidPassphrase,
+		},
+		{
+			name:       "tampered salt",
+			passphrase: passphrase,
+			version:    SchemaVersion,
+			tamper:     func(enc *encryption, _ []byte) { enc.Salt[0] ^= 1 },
+			err:        ErrInvalidPassphrase,
+		},
+		{
+			name:       "tampered nonce",
+			passphrase: passphrase,
+			version:    SchemaVersion,
+			tamper:     func(enc *encryption, _ []byte) { enc.Nonce[0] ^= 1 },
+			err:        ErrInvalidPassphrase,
+		},
+		{
+			name:       "tampered ciphertext",
+			passphrase: passphrase,
+			version:    SchemaVersion,
+			tamper:     func(_ *encryption, ciphertext []byte) { ciphertext[0] ^= 1 },
+			err:        ErrInvalidPassphrase,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc, ciphertext, err := seal(key, bytes.Clone(salt), plaintext, SchemaVersion)
+			if err != nil {
+				t.Fatalf("seal() error: %v", err)
+			}
+			if bytes.Contains(ciphertext, plaintext) {
+				t.Fatal("seal() ciphertext contains plaintext")
+			}
+			if tt.tamper != nil {
+				tt.tamper(enc, ciphertext)
+			}
+
+			got, gotKey, err := open(enc, ciphertext, tt.passphrase, tt.version)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("open() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Errorf("open() = %s, want %s", got, plaintext)
+			}
+			if !bytes.Equal(gotKey, key) {
+				t.Error("open() returned key differs from sealing key")
+			}
+		})
+	}
+}
+
+func TestOpenUnsupportedEncryption(t *testing.T) {
+	enc := &encryption{KDF: "argon2id", KDFParams: defaultScryptParams, Cipher: cipherAES256GCM}
+	if _, _, err := open(enc, nil, []byte("passphrase"), SchemaVersion); err == nil || errors.Is(err, ErrInvalidPassphrase) {
+		t.Errorf("open() error = %v, want unsupported encryption", err)
+	}
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/cardano-community/koios-go-client/v4"
+	"github.com/happy-sdk/happy"
+	"github.com/happy-sdk/happy/pkg/strings/textfmt"
+	"github.com/happy-sdk/happy/sdk/logging"
+)
+
+// DefaultExpiryWarnDays is number of days before token expiry
+// when warning about upcoming expiry is logged.
+const DefaultExpiryWarnDays = 7
+
+var ErrTokenExpired = errors.New("token has expired")
+
+// CheckExpiry returns ErrTokenExpired when token has expired and
+// logs warning when token expires within warnDays.
+// Tokens without expiration date are always valid.
+func CheckExpiry(log logging.Logger, authInfo koios.AuthInfo, warnDays uint) error {
+	expires := authInfo.Expires.Time()
+	if expires.IsZero() {
+		return nil
+	}
+	left := time.Until(expires)
+	if left <= 0 {
+		return fmt.Errorf("%w on %s", ErrTokenExpired, authInfo.Expires.String())
+	}
+	if left <= time.Duration(warnDays)*24*time.Hour {
+		log.Warn("token expires soon, renew it with: koios-cli auth renew <profile> <jwt-token>",
+			slog.String("project", authInfo.ProjID),
+			slog.String("expires", authInfo.Expires.String()),
+			slog.Int("days-left", int(math.Ceil(left.Hours()/24))),
+		)
+	}
+	return nil
+}
+
+// expiryStatus describes token expiry for profile listing.
+func expiryStatus(authInfo koios.AuthInfo, warnDays uint) string {
+	expires := authInfo.Expires.Time()
+	if expires.IsZero() {
+		return "valid"
+	}
+	left := time.Until(expires)
+	switch {
+	case left <= 0:
+		return "expired"
+	case left <= time.Duration(warnDays)*24*time.Hour:
+		return fmt.Sprintf("expires in %d days", int(math.Ceil(left.Hours()/24)))
+	default:
+		return "valid"
+	}
+}
+
+func cmdAuthRenew() *happy.Command {
+	cmd := happy.NewCommand("renew",
+		happy.Option("description", "Replace expired or expiring token of profile"),
+		happy.Option("argn.min", 2),
+		happy.Option("argn.max", 2),
+		happy.Option("usage", "koios auth renew <profile-name> <token>"),
+	)
+
+	cmd.AddInfo("Replace subscription token of profile with new token generated via https://koios.rest Profile page")
+	cmd.AddInfo(`
+    Request history and encryption of the profile are kept. New token
+    must be issued for the same project ID as the token it replaces.
+
+    Example: koios-cli auth renew my-project eyJhbGciOiJ...-JGHedpgsQVsI
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		profileName, koiosAuthFile, err := profileFile(sess, args.Arg(0).String())
+		if err != nil {
+			return err
+		}
+		token := strings.TrimSpace(args.Arg(1).String())
+		authInfo, err := koios.GetTokenAuthInfo(token)
+		if err != nil {
+			return err
+		}
+		if err := CheckExpiry(sess.Log(), authInfo, 0); err != nil {
+			return fmt.Errorf("new token can not be used: %w", err)
+		}
+
+		sub, err := LoadSubscriptionFile(koiosAuthFile)
+		if err != nil {
+			return err
+		}
+		current, err := koios.GetTokenAuthInfo(sub.JWT)
+		if err != nil {
+			return err
+		}
+		if current.ProjID != authInfo.ProjID {
+			return fmt.Errorf("token is issued for project %s, profile %s belongs to project %s", authInfo.ProjID, profileName, current.ProjID)
+		}
+
+		sub.JWT = token
+		if err := sub.Save(); err != nil {
+			return fmt.Errorf("failed to save subscription: %w", err)
+		}
+
+		infotbl := textfmt.Table{
+			Title: "Renewed Token Information",
+		}
+		infotbl.AddRow("Profile", profileName)
+		infotbl.AddRow("Tier", authInfo.Tier.String())
+		infotbl.AddRow("Previous Expires", current.Expires.String())
+		infotbl.AddRow("Expires", authInfo.Expires.String())
+		fmt.Println(infotbl.String())
+
+		sess.Log().Ok("token renewed", slog.String("profile", profileName))
+		return nil
+	})
+
+	return cmd
+}
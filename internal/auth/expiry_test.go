@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cardano-community/koios-go-client/v4"
+	"github.com/happy-sdk/happy/sdk/logging"
+)
+
+const day = 24 * time.Hour
+
+func authInfoExpiring(in time.Duration) koios.AuthInfo {
+	info := koios.AuthInfo{ProjID: "koios-cli"}
+	if in != 0 {
+		info.Expires = koios.AuthExpires(time.Now().Add(in))
+	}
+	return info
+}
+
+func TestCheckExpiry(t *testing.T) {
+	tests := []struct {
+		name     string
+		expires  time.Duration
+		warnDays uint
+		expired  bool
+		warning  string
+	}{
+		{name: "no expiry", warnDays: DefaultExpiryWarnDays},
+		{name: "expired", expires: -time.Minute, warnDays: DefaultExpiryWarnDays, expired: true},
+		{name: "expires within warn days", expires: 3*day - time.Minute, warnDays: DefaultExpiryWarnDays, warning: `"days-left":3`},
+		{name: "expires today", expires: time.Hour, warnDays: DefaultExpiryWarnDays, warning: `"days-left":1`},
+		{name: "expires on last warn day", expires: 7*day - time.Minute, warnDays: 7, warning: `"days-left":7`},
+		{name: "expires after warn days", expires: 10 * day, warnDays: DefaultExpiryWarnDays},
+		{name: "warning disabled", expires: time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logging.NewTestLogger(logging.LevelDebug)
+			err := CheckExpiry(log, authInfoExpiring(tt.expires), tt.warnDays)
+			if errors.Is(err, ErrTokenExpired) != tt.expired {
+				t.Errorf("CheckExpiry() error = %v, want expired %t", err, tt.expired)
+			}
+			if !tt.expired && err != nil {
+				t.Errorf("CheckExpiry() error: %v", err)
+			}
+
+			out := log.Output()
+			switch {
+			case tt.warning == "" && out != "":
+				t.Errorf("CheckExpiry() logged %s, want no warning", out)
+			case tt.warning != "" && (!strings.Contains(out, "token expires soon") || !strings.Contains(out, tt.warning)):
+				t.Errorf("CheckExpiry() logged %q, want warning with %s", out, tt.warning)
+			}
+		})
+	}
+}
+
+func TestExpiryStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		expires time.Duration
+		status  string
+	}{
+		{name: "no expiry", status: "valid"},
+		{name: "expired", expires: -time.Minute, status: "expired"},
+		{name: "expires within warn days", expires: 3*day - time.Minute, status: "expires in 3 days"},
+		{name: "expires after warn days", expires: 10 * day, status: "valid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := expiryStatus(authInfoExpiring(tt.expires), DefaultExpiryWarnDays); status != tt.status {
+				t.Errorf("expiryStatus() = %q, want %q", status, tt.status)
+			}
+		})
+	}
+}
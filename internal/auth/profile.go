@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/happy-sdk/happy"
+	"github.com/happy-sdk/happy/pkg/vars/varflag"
+)
+
+const (
+	// ProfileEnv is environment variable selecting profile
+	// used when --profile flag is not set.
+	ProfileEnv = "KOIOS_PROFILE"
+	// AuthEnv is environment variable holding JWT token
+	// used when --profile and --auth flags are not set.
+	AuthEnv = "KOIOS_AUTH"
+
+	defaultProfileFile = "default-profile"
+)
+
+// Sources of active profile or token in order of precedence.
+const (
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceDefault = "default"
+)
+
+// ActiveProfile returns name and subscription file of profile used when
+// --profile flag is not set, from ProfileEnv or default profile set with
+// auth use, together with source it was selected from.
+// It returns empty name when no profile is selected.
+func ActiveProfile(sess *happy.Session) (name, file, source string, err error) {
+	if name := os.Getenv(ProfileEnv); name != "" {
+		name, file, err := profileFile(sess, name)
+		if err != nil {
+			return "", "", "", fmt.Errorf("%s: %w", ProfileEnv, err)
+		}
+		return name, file, SourceEnv, nil
+	}
+	name, err = DefaultProfile(sess)
+	if err != nil || name == "" {
+		return "", "", "", err
+	}
+	name, file, err = profileFile(sess, name)
+	if err != nil {
+		return "", "", "", fmt.Errorf("default profile: %w, change it with: koios-cli auth use <profile>", err)
+	}
+	return name, file, SourceDefault, nil
+}
+
+// DefaultProfile returns name of default profile set with auth use.
+func DefaultProfile(sess *happy.Session) (string, error) {
+	data, err := os.ReadFile(defaultProfilePath(sess))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read default profile: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func setDefaultProfile(sess *happy.Session, name string) error {
+	file := defaultProfilePath(sess)
+	if name == "" {
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to unset default profile: %w", err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(file, []byte(name+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to save default profile: %w", err)
+	}
+	return nil
+}
+
+// defaultProfilePath returns path of file holding default profile name.
+func defaultProfilePath(sess *happy.Session) string {
+	return filepath.Join(ConfigDir(sess), defaultProfileFile)
+}
+
+// ConfigDir returns config directory shared by all profiles.
+func ConfigDir(sess *happy.Session) string {
+	return filepath.Dir(profilesDir(sess))
+}
+
+// ProfileDir returns directory of named profile.
+func ProfileDir(sess *happy.Session, name string) string {
+	return filepath.Join(profilesDir(sess), name)
+}
+
+// activeProfileName returns name of the profile used by api commands
+// and source it was selected from.
+func activeProfileName(sess *happy.Session, args happy.Args) (string, string) {
+	if args.Flag("profile").Present() {
+		return sess.Get("app.profile.name").String(), SourceFlag
+	}
+	if os.Getenv(AuthEnv) != "" {
+		return "", SourceEnv
+	}
+	name, _, source, err := ActiveProfile(sess)
+	if err != nil {
+		return "", ""
+	}
+	return name, source
+}
+
+func cmdAuthUse() *happy.Command {
+	cmd := happy.NewCommand("use",
+		happy.Option("description", "Set default profile used when --profile flag is not set"),
+		happy.Option("argn.max", 1),
+		happy.Option("usage", "koios auth use <profile-name>"),
+	).WithFlags(
+		varflag.BoolFunc("unset", false, "Unset default profile and use public tier"),
+	)
+
+	cmd.AddInfo("Set default profile used by api commands")
+	cmd.AddInfo(`
+    Profile used by api commands is selected in following order:
+
+      1. --profile or --auth flag
+      2. ` + ProfileEnv + ` or ` + AuthEnv + ` environment variable
+      3. default profile set with this command
+
+    Example: koios-cli auth use my-project
+    Example: koios-cli auth use --unset
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		if args.Flag("unset").Var().Bool() {
+			if err := setDefaultProfile(sess, ""); err != nil {
+				return err
+			}
+			sess.Log().Ok("default profile unset")
+			return nil
+		}
+		if args.Argn() == 0 {
+			name, err := DefaultProfile(sess)
+			if err != nil {
+				return err
+			}
+			if name == "" {
+				fmt.Println("No default profile set")
+				return nil
+			}
+			fmt.Println(name)
+			return nil
+		}
+
+		name := strings.TrimSpace(args.Arg(0).String())
+		if _, _, err := profileFile(sess, name); err != nil {
+			return err
+		}
+		if err := setDefaultProfile(sess, name); err != nil {
+			return err
+		}
+		sess.Log().Ok("default profile set", slog.String("profile", name))
+		return nil
+	})
+
+	return cmd
+}
+
+// LoadProfile loads subscription of named profile.
+// It returns name of the profile as stored on disk.
+func LoadProfile(sess *happy.Session, name string) (string, *Subscription, error) {
+	name, file, err := profileFile(sess, name)
+	if err != nil {
+		return "", nil, err
+	}
+	sub, err := LoadSubscriptionFile(file)
+	if err != nil {
+		return "", nil, err
+	}
+	return name, sub, nil
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package auth
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SchemaVersion is current version of subscription file schema.
+//
+//	0 - gob encoded subscription, read only
+//	1 - json document
+const SchemaVersion = 1
+
+// migrations upgrade subscription json document from version n to n+1.
+// Version 0 is gob encoded and upgraded with decodeGob.
+var migrations = map[int]func(doc map[string]json.RawMessage) error{}
+
+// encryptedDocument is stored instead of subscription document
+// when profile is encrypted, Data holds sealed subscription document.
+type encryptedDocument struct {
+	Version    int         `json:"version"`
+	Encryption *encryption `json:"encryption"`
+	Data       []byte      `json:"data"`
+}
+
+// marshal returns subscription document in current schema,
+// sealed when subscription is encrypted.
+func (s *Subscription) marshal() ([]byte, error) {
+	doc, err := json.MarshalIndent(struct {
+		Version int `json:"version"`
+		*Subscription
+	}{SchemaVersion, s}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if !s.Encrypted() {
+		return doc, nil
+	}
+
+	enc, data, err := seal(s.key, s.salt, doc, SchemaVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt subscription: %w", err)
+	}
+	return json.MarshalIndent(encryptedDocument{
+		Version:    SchemaVersion,
+		Encryption: enc,
+		Data:       data,
+	}, "", "  ")
+}
+
+// decodeSubscription decodes subscription file of any known schema version.
+// Encrypted documents are unlocked with passphrase asked with prompt.
+// It reports whether document was upgraded to current schema version.
+func decodeSubscription(data []byte, prompt string) (sub *Subscription, upgraded bool, err error) {
+	data = bytes.TrimSpace(data)
+	sub = &Subscription{}
+	var upgradeKey bool
+
+	switch {
+	case len(data) > 0 && data[0] == '{':
+		var encrypted encryptedDocument
+		if err := json.Unmarshal(data, &encrypted); err != nil {
+			return nil, false, fmt.Errorf("failed to decode subscription: %w", err)
+		}
+		if encrypted.Encryption != nil {
+			passphrase, err := readPassphrase(prompt, false)
+			if err != nil {
+				return nil, false, err
+			}
+			if data, sub.key, err = open(encrypted.Encryption, encrypted.Data, passphrase, encrypted.Version); err != nil {
+				return nil, false, err
+			}
+			sub.salt = encrypted.Encryption.Salt
+			// key is sealed again with default parameters on save
+			if encrypted.Encryption.KDFParams != defaultScryptParams {
+				if err := sub.Encrypt(passphrase); err != nil {
+					return nil, false, err
+				}
+				upgradeKey = true
+			}
+		}
+		upgraded, err := decodeDocument(data, sub)
+		return sub, upgraded || upgradeKey, err
+	default:
+		return sub, true, decodeGob(data, sub)
+	}
+}
+
+// decodeDocument decodes subscription json document applying
+// migrations when document has older schema version.
+func decodeDocument(data []byte, sub *Subscription) (bool, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return false, fmt.Errorf("failed to decode subscription: %w", err)
+	}
+	var version int
+	if err := json.Unmarshal(doc["version"], &version); err != nil {
+		return false, errors.New("subscription schema version is missing")
+	}
+	if version > SchemaVersion {
+		return false, fmt.Errorf("subscription schema version %d is newer than supported version %d, upgrade koios-cli", version, SchemaVersion)
+	}
+
+	for v := version; v < SchemaVersion; v++ {
+		migrate, ok := migrations[v]
+		if !ok {
+			return false, fmt.Errorf("no migration from subscription schema version %d", v)
+		}
+		if err := migrate(doc); err != nil {
+			return false, fmt.Errorf("failed to migrate subscription schema version %d: %w", v, err)
+		}
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, sub); err != nil {
+		return false, fmt.Errorf("failed to decode subscription: %w", err)
+	}
+	return version < SchemaVersion, nil
+}
+
+// decodeGob decodes schema version 0 subscription.
+func decodeGob(data []byte, sub *Subscription) error {
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(sub); err != nil {
+		return fmt.Errorf("failed to decode auth token file: %w", err)
+	}
+	return nil
+}
+
+// isEncryptedDocument reports whether subscription file content is encrypted.
+func isEncryptedDocument(data []byte) bool {
+	var encrypted encryptedDocument
+	return json.Unmarshal(data, &encrypted) == nil && encrypted.Encryption != nil
+}
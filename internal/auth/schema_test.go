@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package auth
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// legacySubscription is subscription as it was gob encoded
+// before schema versions were introduced.
+type legacySubscription struct {
+	JWT           string
+	RequestsToday uint
+	Date          string
+	History       []RequestHistoryEntry
+}
+
+func TestLoadSubscriptionFileMigratesGob(t *testing.T) {
+	legacy := legacySubscription{
+		JWT:           "header.payload.signature",
+		RequestsToday: 42,
+		Date:          time.Now().Format("2006-01-02"),
+		History:       []RequestHistoryEntry{{Date: "2024-06-01", Requests: 7}},
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(legacy); err != nil {
+		t.Fatal(err)
+	}
+	koiosAuthFile := filepath.Join(t.TempDir(), "my-project", "koios.subscription")
+	if err := os.MkdirAll(filepath.Dir(koiosAuthFile), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(koiosAuthFile, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := LoadSubscriptionFile(koiosAuthFile)
+	if err != nil {
+		t.Fatalf("LoadSubscriptionFile() error: %v", err)
+	}
+	if sub.JWT != legacy.JWT || sub.RequestsToday != legacy.RequestsToday ||
+		sub.Date != legacy.Date || !reflect.DeepEqual(sub.History, legacy.History) {
+		t.Errorf("LoadSubscriptionFile() = %+v, want %+v", sub, legacy)
+	}
+
+	data, err := os.ReadFile(koiosAuthFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Version int `json:"version"`
+		Subscription
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("migrated subscription is not json document: %v", err)
+	}
+	if doc.Version != SchemaVersion || doc.JWT != legacy.JWT || doc.RequestsToday != legacy.RequestsToday {
+		t.Errorf("migrated subscription = %s, want version %d of %+v", data, SchemaVersion, legacy)
+	}
+}
+
+func TestDecodeDocumentVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		err  string
+	}{
+		{name: "current version", doc: `{"version":1,"jwt":"token"}`},
+		{name: "missing version", doc: `{"jwt":"token"}`, err: "schema version is missing"},
+		{name: "newer version", doc: `{"version":2,"jwt":"token"}`, err: "newer than supported version"},
+		{name: "unknown older version", doc: `{"version":0,"jwt":"token"}`, err: "no migration"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &Subscription{}
+			upgraded, err := decodeDocument([]byte(tt.doc), sub)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("decodeDocument() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeDocument() error: %v", err)
+			}
+			if upgraded || sub.JWT != "token" {
+				t.Errorf("decodeDocument() = %+v, upgraded %t", sub, upgraded)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cardano-community/koios-go-client/v4"
+	"github.com/happy-sdk/happy"
+	"github.com/happy-sdk/happy/pkg/strings/textfmt"
+	"github.com/happy-sdk/happy/pkg/vars/varflag"
+)
+
+const dateLayout = "2006-01-02"
+
+var usagePeriods = []string{"day", "week", "month"}
+
+type usageReport struct {
+	Profile       string          `json:"profile"`
+	Tier          string          `json:"tier"`
+	MaxRequests   uint            `json:"max_requests,omitempty"`
+	Date          string          `json:"date"`
+	RequestsToday uint            `json:"requests_today"`
+	Projection    usageProjection `json:"projection"`
+	Period        string          `json:"period"`
+	Usage         []usageEntry    `json:"usage"`
+	daily         []usageEntry
+}
+
+type usageEntry struct {
+	Period   string `json:"period"`
+	Requests uint   `json:"requests"`
+	// Quota is share of the quota of the period used, 0 when tier has no limit.
+	Quota float64 `json:"quota_used,omitempty"`
+}
+
+type usageProjection struct {
+	RequestsPerHour float64    `json:"requests_per_hour"`
+	ProjectedToday  uint       `json:"projected_today"`
+	ExhaustedAt     *time.Time `json:"exhausted_at,omitempty"`
+}
+
+func cmdAuthUsage() *happy.Command {
+	cmd := happy.NewCommand("usage",
+		happy.Option("description", "Show request usage history and quota forecast of profile"),
+		happy.Option("argn.min", 1),
+		happy.Option("argn.max", 1),
+		happy.Option("usage", "koios auth usage <profile-name>"),
+	).WithFlags(
+		varflag.StringFunc("period", "day", "Aggregate usage by period: "+strings.Join(usagePeriods, "|")),
+		varflag.UintFunc("days", 30, "Number of days of history to include"),
+		varflag.StringFunc("output", "table", "Set output format: table|json", "o"),
+	)
+
+	cmd.AddInfo("Show daily, weekly or monthly request counts compared to the daily quota of the subscription tier")
+	cmd.AddInfo(`
+    Forecast projects when daily quota will be exhausted at the current request rate.
+
+    Example: koios-cli auth usage my-project
+    Example: koios-cli auth usage --period week --days 90 my-project
+    Example: koios-cli auth usage -o json my-project
+  `)
+
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		period := args.Flag("period").String()
+		if !slices.Contains(usagePeriods, period) {
+			return fmt.Errorf("unknown period %q, supported periods are: %s", period, strings.Join(usagePeriods, ", "))
+		}
+		output := args.Flag("output").String()
+		if output != "table" && output != "json" {
+			return fmt.Errorf("unknown output format %q, supported formats are: table, json", output)
+		}
+
+		profileName, koiosAuthFile, err := profileFile(sess, args.Arg(0).String())
+		if err != nil {
+			return err
+		}
+		sub, err := LoadSubscriptionFile(koiosAuthFile)
+		if err != nil {
+			return err
+		}
+		authInfo, err := koios.GetTokenAuthInfo(sub.JWT)
+		if err != nil {
+			return err
+		}
+
+		report := newUsageReport(sub, authInfo, period, int(args.Flag("days").Var().Uint()), time.Now())
+		report.Profile = profileName
+
+		if output == "json" {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		fmt.Println(report.String())
+		return nil
+	})
+
+	return cmd
+}
+
+// newUsageReport creates usage report of the subscription for last days until now.
+func newUsageReport(sub *Subscription, authInfo koios.AuthInfo, period string, days int, now time.Time) *usageReport {
+	report := &usageReport{
+		Tier:          authInfo.Tier.String(),
+		Date:          sub.Date,
+		RequestsToday: sub.RequestsToday,
+		Period:        period,
+	}
+	// custom tier has no request limit
+	if authInfo.MaxRequests != math.MaxUint {
+		report.MaxRequests = authInfo.MaxRequests
+	}
+
+	requests := make(map[string]uint, len(sub.History)+1)
+	for _, entry := range sub.History {
+		requests[entry.Date] += entry.Requests
+	}
+	requests[sub.Date] += sub.RequestsToday
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for i := max(days, 1) - 1; i >= 0; i-- {
+		day := today.AddDate(0, 0, -i)
+		date := day.Format(dateLayout)
+		report.daily = append(report.daily, usageEntry{
+			Period:   date,
+			Requests: requests[date],
+			Quota:    report.quota(requests[date], 1),
+		})
+
+		var label string
+		var periodDays int
+		switch period {
+		case "week":
+			year, week := day.ISOWeek()
+			label, periodDays = fmt.Sprintf("%d-W%02d", year, week), 7
+		case "month":
+			label = day.Format("2006-01")
+			periodDays = time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+		default:
+			label, periodDays = date, 1
+		}
+		if n := len(report.Usage); n > 0 && report.Usage[n-1].Period == label {
+			report.Usage[n-1].Requests += requests[date]
+			report.Usage[n-1].Quota = report.quota(report.Usage[n-1].Requests, periodDays)
+			continue
+		}
+		report.Usage = append(report.Usage, usageEntry{
+			Period:   label,
+			Requests: requests[date],
+			Quota:    report.quota(requests[date], periodDays),
+		})
+	}
+
+	// project requests of today from the rate since midnight
+	if elapsed := now.Sub(today).Hours(); elapsed > 0 && sub.Date == today.Format(dateLayout) {
+		rate := float64(sub.RequestsToday) / elapsed
+		report.Projection.RequestsPerHour = math.Round(rate*100) / 100
+		report.Projection.ProjectedToday = uint(rate * 24)
+		if report.MaxRequests > 0 && rate > 0 && report.Projection.ProjectedToday >= report.MaxRequests {
+			exhausted := today.Add(time.Duration(float64(report.MaxRequests) / rate * float64(time.Hour)))
+			if exhausted.Before(now) {
+				exhausted = now
+			}
+			report.Projection.ExhaustedAt = &exhausted
+		}
+	}
+	return report
+}
+
+// quota returns share of quota of period days used by requests.
+func (r *usageReport) quota(requests uint, days int) float64 {
+	if r.MaxRequests == 0 {
+		return 0
+	}
+	return math.Round(float64(requests)/float64(r.MaxRequests*uint(days))*10000) / 10000
+}
+
+func (r *usageReport) String() string {
+	info := textfmt.Table{
+		Title: "Usage of profile " + r.Profile,
+	}
+	info.AddRow("Tier", r.Tier)
+	if r.MaxRequests > 0 {
+		info.AddRow("Max Requests/day", fmt.Sprint(r.MaxRequests))
+	} else {
+		info.AddRow("Max Requests/day", "unlimited")
+	}
+	info.AddRow("Requests Today", fmt.Sprint(r.RequestsToday))
+	info.AddDivider()
+	info.AddRow("Requests/hour", fmt.Sprint(r.Projection.RequestsPerHour))
+	info.AddRow("Projected Today", fmt.Sprint(r.Projection.ProjectedToday))
+	switch {
+	case r.Projection.ExhaustedAt != nil:
+		info.AddRow("Quota Exhausted At", r.Projection.ExhaustedAt.Format("2006-01-02 15:04"))
+	case r.MaxRequests > 0:
+		info.AddRow("Quota Exhausted At", "not at current rate")
+	}
+	info.AddDivider()
+	info.AddRow("Last "+fmt.Sprint(len(r.daily))+" days", sparkline(r.daily))
+
+	usage := textfmt.Table{
+		WithHeader: true,
+	}
+	usage.AddRow(strings.ToUpper(r.Period[:1])+r.Period[1:], "Requests", "Quota Used")
+	for _, entry := range r.Usage {
+		quota := "-"
+		if r.MaxRequests > 0 {
+			quota = fmt.Sprintf("%.2f%%", entry.Quota*100)
+		}
+		usage.AddRow(entry.Period, fmt.Sprint(entry.Requests), quota)
+	}
+	return info.String() + "\n" + usage.String()
+}
+
+// sparkline renders request counts of entries as single line chart.
+func sparkline(entries []usageEntry) string {
+	ticks := []rune("▁▂▃▄▅▆▇█")
+	var peak uint
+	for _, entry := range entries {
+		peak = max(peak, entry.Requests)
+	}
+	var line strings.Builder
+	for _, entry := range entries {
+		if peak == 0 {
+			line.WriteRune(ticks[0])
+			continue
+		}
+		line.WriteRune(ticks[int(entry.Requests*uint(len(ticks)-1)/peak)])
+	}
+	return line.String()
+}
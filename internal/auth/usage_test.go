@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package auth
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cardano-community/koios-go-client/v4"
+)
+
+func TestNewUsageReportPeriods(t *testing.T) {
+	// history across year boundary, 2024-12-30 is monday of week 1 of 2025
+	sub := &Subscription{
+		Date:          "2025-01-02",
+		RequestsToday: 60,
+		History: []RequestHistoryEntry{
+			{Date: "2024-12-27", Requests: 5},
+			{Date: "2024-12-28", Requests: 10},
+			{Date: "2024-12-29", Requests: 20},
+			{Date: "2024-12-30", Requests: 30},
+			{Date: "2024-12-31", Requests: 40},
+			{Date: "2025-01-01", Requests: 50},
+		},
+	}
+	authInfo := koios.AuthInfo{MaxRequests: 1000}
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		period string
+		days   int
+		usage  []usageEntry
+	}{
+		{
+			period: "day",
+			days:   3,
+			usage: []usageEntry{
+				{Period: "2024-12-31", Requests: 40, Quota: 0.04},
+				{Period: "2025-01-01", Requests: 50, Quota: 0.05},
+				{Period: "2025-01-02", Requests: 60, Quota: 0.06},
+			},
+		},
+		{
+			period: "week",
+			days:   6,
+			usage: []usageEntry{
+				{Period: "2024-W52", Requests: 30, Quota: 0.0043},
+				{Period: "2025-W01", Requests: 180, Quota: 0.0257},
+			},
+		},
+		{
+			period: "month",
+			days:   6,
+			usage: []usageEntry{
+				{Period: "2024-12", Requests: 100, Quota: 0.0032},
+				{Period: "2025-01", Requests: 110, Quota: 0.0035},
+			},
+		},
+		{
+			period: "day",
+			days:   0,
+			usage: []usageEntry{
+				{Period: "2025-01-02", Requests: 60, Quota: 0.06},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			report := newUsageReport(sub, authInfo, tt.period, tt.days, now)
+			if !reflect.DeepEqual(report.Usage, tt.usage) {
+				t.Errorf("newUsageReport() usage = %+v, want %+v", report.Usage, tt.usage)
+			}
+			if len(report.daily) != max(tt.days, 1) {
+				t.Errorf("newUsageReport() has %d daily entries, want %d", len(report.daily), max(tt.days, 1))
+			}
+		})
+	}
+}
+
+func TestNewUsageReportWeek53(t *testing.T) {
+	// 2021-01-03 is sunday of week 53 of 2020
+	sub := &Subscription{
+		Date:          "2021-01-04",
+		RequestsToday: 2,
+		History:       []RequestHistoryEntry{{Date: "2021-01-03", Requests: 1}},
+	}
+	now := time.Date(2021, 1, 4, 8, 0, 0, 0, time.UTC)
+	want := []usageEntry{
+		{Period: "2020-W53", Requests: 1},
+		{Period: "2021-W01", Requests: 2},
+	}
+	report := newUsageReport(sub, koios.AuthInfo{MaxRequests: math.MaxUint}, "week", 2, now)
+	if !reflect.DeepEqual(report.Usage, want) {
+		t.Errorf("newUsageReport() usage = %+v, want %+v", report.Usage, want)
+	}
+}
+
+func TestNewUsageReportProjection(t *testing.T) {
+	midnight := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		date        string
+		requests    uint
+		maxRequests uint
+		now         time.Time
+		projection  usageProjection
+	}{
+		{
+			name:        "at midnight",
+			date:        "2025-01-02",
+			maxRequests: 5000,
+			now:         midnight,
+		},
+		{
+			name:        "requests of previous day at midnight",
+			date:        "2025-01-01",
+			requests:    4000,
+			maxRequests: 5000,
+			now:         midnight,
+		},
+		{
+			name:        "after midnight",
+			date:        "2025-01-02",
+			requests:    600,
+			maxRequests: 5000,
+			now:         midnight.Add(30 * time.Minute),
+			projection: usageProjection{
+				RequestsPerHour: 1200,
+				ProjectedToday:  28800,
+				ExhaustedAt:     ptr(midnight.Add(4*time.Hour + 10*time.Minute)),
+			},
+		},
+		{
+			name:        "quota not exhausted",
+			date:        "2025-01-02",
+			requests:    1000,
+			maxRequests: 5000,
+			now:         midnight.Add(12 * time.Hour),
+			projection:  usageProjection{RequestsPerHour: 83.33, ProjectedToday: 2000},
+		},
+		{
+			name:        "quota already exhausted",
+			date:        "2025-01-02",
+			requests:    6000,
+			maxRequests: 5000,
+			now:         midnight.Add(6 * time.Hour),
+			projection: usageProjection{
+				RequestsPerHour: 1000,
+				ProjectedToday:  24000,
+				ExhaustedAt:     ptr(midnight.Add(6 * time.Hour)),
+			},
+		},
+		{
+			name:        "no request limit",
+			date:        "2025-01-02",
+			requests:    6000,
+			maxRequests: math.MaxUint,
+			now:         midnight.Add(6 * time.Hour),
+			projection:  usageProjection{RequestsPerHour: 1000, ProjectedToday: 24000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &Subscription{Date: tt.date, RequestsToday: tt.requests}
+			report := newUsageReport(sub, koios.AuthInfo{MaxRequests: tt.maxRequests}, "day", 1, tt.now)
+			if !reflect.DeepEqual(report.Projection, tt.projection) {
+				t.Errorf("newUsageReport() projection = %+v, want %+v", report.Projection, tt.projection)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
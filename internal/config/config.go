@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/cardano-community/koios-cli/v2/internal/auth"
+	"github.com/happy-sdk/happy"
+	"github.com/happy-sdk/happy/pkg/strings/textfmt"
+)
+
+// Sources of setting values in order of precedence, config files
+// are reported with their path.
+const (
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceDefault = "default"
+)
+
+// Config is profile config file layered over global config file.
+type Config struct {
+	// files in order of precedence
+	files []*File
+}
+
+// Load loads global config file and config file of the profile
+// when profile is not empty.
+func Load(sess *happy.Session, profile string) (*Config, error) {
+	cfg := &Config{}
+	paths := []string{filepath.Join(auth.ConfigDir(sess), FileName)}
+	if profile != "" {
+		paths = append([]string{filepath.Join(auth.ProfileDir(sess, profile), FileName)}, paths...)
+	}
+	for _, path := range paths {
+		f, err := LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		cfg.files = append(cfg.files, f)
+	}
+	return cfg, nil
+}
+
+// Get returns value of the key and path of config file it was read from.
+func (c *Config) Get(key string) (value, source string, ok bool) {
+	for _, f := range c.files {
+		if value, ok := f.Get(key); ok {
+			return value, f.Path, true
+		}
+	}
+	return "", "", false
+}
+
+// Env returns name of environment variable of the setting, e.g. KOIOS_RATE_LIMIT.
+func Env(key string) string {
+	return "KOIOS_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+func Command() *happy.Command {
+	cmd := happy.NewCommand("config",
+		happy.Option("description", "Manage default settings of api client"),
+	)
+
+	cmd.AddInfo("Manage config files supplying defaults for api command flags")
+	cmd.AddInfo(`
+    Settings are read from global config file and config file of the profile
+    selected with --profile flag, which takes precedence over global one.
+    Settings can be set also per network as networks.<network>.<key>, which
+    apply when network is selected with api --network flag. Networks defined
+    this way are added to built-in network presets, see config networks.
+
+    Flags take precedence over environment variables (e.g. KOIOS_HOST),
+    which take precedence over config files. Source of each setting
+    is logged with --debug flag.
+
+    Supported keys: ` + strings.Join(Keys(), ", ") + `
+
+    Example: koios-cli config set rate-limit 5
+    Example: koios-cli config set networks.preprod.host preprod.example.com
+    Example: koios-cli --profile <project-id> config set timeout 2m
+    Example: koios-cli config set network preprod
+    Example: koios-cli config list
+  `)
+
+	cmd.AddSubCommand(cmdConfigGet())
+	cmd.AddSubCommand(cmdConfigSet())
+	cmd.AddSubCommand(cmdConfigUnset())
+	cmd.AddSubCommand(cmdConfigList())
+	cmd.AddSubCommand(cmdConfigEdit())
+	cmd.AddSubCommand(cmdConfigNetworks())
+	return cmd
+}
+
+func cmdConfigGet() *happy.Command {
+	cmd := happy.NewCommand("get",
+		happy.Option("description", "Print value of setting"),
+		happy.Option("argn.min", 1),
+		happy.Option("argn.max", 1),
+		happy.Option("usage", "koios config get <key>"),
+	)
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		key := args.Arg(0).String()
+		cfg, err := Load(sess, selectedProfile(sess, args))
+		if err != nil {
+			return err
+		}
+		value, source, ok := cfg.Get(key)
+		if !ok {
+			return fmt.Errorf("config key %s is not set", key)
+		}
+		sess.Log().Debug("config value", slog.String("key", key), slog.String("source", source))
+		fmt.Println(value)
+		return nil
+	})
+	return cmd
+}
+
+func cmdConfigSet() *happy.Command {
+	cmd := happy.NewCommand("set",
+		happy.Option("description", "Set value of setting"),
+		happy.Option("argn.min", 2),
+		happy.Option("argn.max", 2),
+		happy.Option("usage", "koios config set <key> <value>"),
+	)
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		f, err := selectedFile(sess, args)
+		if err != nil {
+			return err
+		}
+		key, value := args.Arg(0).String(), strings.TrimSpace(args.Arg(1).String())
+		if err := f.Set(key, value); err != nil {
+			return err
+		}
+		if err := f.Save(); err != nil {
+			return err
+		}
+		sess.Log().Ok("config saved", slog.String(key, value), slog.String("path", f.Path))
+		return nil
+	})
+	return cmd
+}
+
+func cmdConfigUnset() *happy.Command {
+	cmd := happy.NewCommand("unset",
+		happy.Option("description", "Remove setting"),
+		happy.Option("argn.min", 1),
+		happy.Option("argn.max", 1),
+		happy.Option("usage", "koios config unset <key>"),
+	)
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		f, err := selectedFile(sess, args)
+		if err != nil {
+			return err
+		}
+		key := args.Arg(0).String()
+		if !f.Unset(key) {
+			return fmt.Errorf("config key %s is not set in %s", key, f.Path)
+		}
+		if err := f.Save(); err != nil {
+			return err
+		}
+		sess.Log().Ok("config key removed", slog.String("key", key), slog.String("path", f.Path))
+		return nil
+	})
+	return cmd
+}
+
+func cmdConfigList() *happy.Command {
+	cmd := happy.NewCommand("list",
+		happy.Option("description", "List settings and config files they are set in"),
+	)
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		cfg, err := Load(sess, selectedProfile(sess, args))
+		if err != nil {
+			return err
+		}
+		tbl := textfmt.Table{
+			Title:      "Config",
+			WithHeader: true,
+		}
+		tbl.AddRow("Key", "Value", "Source")
+		seen := make(map[string]bool)
+		for _, f := range cfg.files {
+			for _, key := range f.Keys() {
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				value, _ := f.Get(key)
+				tbl.AddRow(key, value, f.Path)
+			}
+		}
+		if len(seen) == 0 {
+			fmt.Println("No settings found")
+			return nil
+		}
+		fmt.Println(tbl.String())
+		return nil
+	})
+	return cmd
+}
+
+func cmdConfigEdit() *happy.Command {
+	cmd := happy.NewCommand("edit",
+		happy.Option("description", "Open config file in $EDITOR"),
+	)
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		path := selectedPath(sess, args)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return fmt.Errorf("failed to create config directory: %w", err)
+		}
+		editor := os.Getenv("VISUAL")
+		if editor == "" {
+			editor = os.Getenv("EDITOR")
+		}
+		if editor == "" {
+			editor = "vi"
+		}
+		fields := strings.Fields(editor)
+		edit := exec.Command(fields[0], append(fields[1:], path)...)
+		edit.Stdin, edit.Stdout, edit.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := edit.Run(); err != nil {
+			return fmt.Errorf("failed to run editor %s: %w", editor, err)
+		}
+		if _, err := LoadFile(path); err != nil {
+			return fmt.Errorf("%w, fix it with: koios-cli config edit", err)
+		}
+		sess.Log().Ok("config saved", slog.String("path", path))
+		return nil
+	})
+	return cmd
+}
+
+// selectedProfile returns profile selected with --profile flag.
+func selectedProfile(sess *happy.Session, args happy.Args) string {
+	if args.Flag("profile").Present() {
+		return sess.Get("app.profile.name").String()
+	}
+	return ""
+}
+
+// selectedPath returns path of config file of profile selected
+// with --profile flag or path of global config file.
+func selectedPath(sess *happy.Session, args happy.Args) string {
+	if profile := selectedProfile(sess, args); profile != "" {
+		return filepath.Join(auth.ProfileDir(sess, profile), FileName)
+	}
+	return filepath.Join(auth.ConfigDir(sess), FileName)
+}
+
+func selectedFile(sess *happy.Session, args happy.Args) (*File, error) {
+	return LoadFile(selectedPath(sess, args))
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is name of config file in config directory and profile directories.
+const FileName = "config.yaml"
+
+// networksKey prefixes settings of network presets, networks.<network>.<key>.
+const networksKey = "networks"
+
+// validators of settings which can be stored in config file.
+var validators = map[string]func(value string) error{
+	"api-version": notEmpty,
+	"host":        notEmpty,
+	"hosts": func(value string) error {
+		for _, host := range strings.Split(value, ",") {
+			host = strings.TrimSpace(host)
+			if host == "" || strings.ContainsAny(host, "/: ") {
+				return fmt.Errorf("invalid host %q, hosts must be comma separated list of host names", host)
+			}
+		}
+		return nil
+	},
+	"network": notEmpty,
+	"origin": func(value string) error {
+		_, err := url.ParseRequestURI(value)
+		return err
+	},
+	"port": func(value string) error {
+		_, err := strconv.ParseUint(value, 10, 16)
+		return err
+	},
+	"rate-limit": func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err == nil && (n < 1 || n > 255) {
+			return errors.New("rate limit must be between 1-255 requests per sec")
+		}
+		return err
+	},
+	"retries": func(value string) error {
+		_, err := strconv.ParseUint(value, 10, 8)
+		return err
+	},
+	"retry-backoff": func(value string) error {
+		_, err := time.ParseDuration(value)
+		return err
+	},
+	"scheme": func(value string) error {
+		if value != "http" && value != "https" {
+			return errors.New("scheme must be http or https")
+		}
+		return nil
+	},
+	"timeout": func(value string) error {
+		_, err := time.ParseDuration(value)
+		return err
+	},
+}
+
+// Keys returns sorted list of settings which can be stored in config file.
+// Each of them except network can be also set per network as networks.<network>.<key>.
+func Keys() []string {
+	var keys []string
+	for key := range validators {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Validate returns error when key is not known setting
+// or value is not valid for the setting.
+func Validate(key, value string) error {
+	name := key
+	if parts := strings.Split(key, "."); len(parts) == 3 && parts[0] == networksKey && parts[1] != "" && parts[2] != "network" {
+		name = parts[2]
+	}
+	validate, ok := validators[name]
+	if !ok {
+		return fmt.Errorf("unknown config key %q, supported keys are: %s and %s.<network>.<key>", key, strings.Join(Keys(), ", "), networksKey)
+	}
+	if err := validate(value); err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+	}
+	return nil
+}
+
+func notEmpty(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return errors.New("value can not be empty")
+	}
+	return nil
+}
+
+// File is single yaml config file. Settings are addressed with
+// dotted keys e.g. host or networks.preprod.host.
+type File struct {
+	Path   string
+	values map[string]string
+}
+
+// LoadFile loads config file from path. Missing file is empty config.
+func LoadFile(path string) (*File, error) {
+	f := &File{
+		Path:   path,
+		values: make(map[string]string),
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return f, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode config file %s: %w", path, err)
+	}
+	flatten("", doc, f.values)
+	for key, value := range f.values {
+		if err := Validate(key, value); err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+	return f, nil
+}
+
+// Get returns value of the key.
+func (f *File) Get(key string) (string, bool) {
+	value, ok := f.values[key]
+	return value, ok
+}
+
+// Set validates and sets value of the key.
+func (f *File) Set(key, value string) error {
+	if err := Validate(key, value); err != nil {
+		return err
+	}
+	f.values[key] = value
+	return nil
+}
+
+// Unset removes the key and reports whether it was set.
+func (f *File) Unset(key string) bool {
+	_, ok := f.values[key]
+	delete(f.values, key)
+	return ok
+}
+
+// Keys returns sorted keys set in the file.
+func (f *File) Keys() []string {
+	var keys []string
+	for key := range f.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Save writes config file, removing it when no keys are set.
+func (f *File) Save() error {
+	if len(f.values) == 0 {
+		if err := os.Remove(f.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove config file: %w", err)
+		}
+		return nil
+	}
+	doc := make(map[string]any)
+	for key, value := range f.values {
+		parts := strings.Split(key, ".")
+		node := doc
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				node[part] = child
+			}
+			node = child
+		}
+		// keep numbers unquoted in yaml
+		if n, err := strconv.Atoi(value); err == nil {
+			node[parts[len(parts)-1]] = n
+		} else {
+			node[parts[len(parts)-1]] = value
+		}
+	}
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	return os.WriteFile(f.Path, data, 0600)
+}
+
+// flatten stores values of nested yaml document with dotted keys.
+func flatten(prefix string, doc map[string]any, values map[string]string) {
+	for key, value := range doc {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			flatten(name, v, values)
+		case nil:
+		default:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+}
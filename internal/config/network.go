@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2024 The Cardano Community Authors
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cardano-community/koios-go-client/v4"
+	"github.com/happy-sdk/happy"
+	"github.com/happy-sdk/happy/pkg/strings/textfmt"
+)
+
+const (
+	// DefaultNetwork is network used when no network is selected.
+	DefaultNetwork = "mainnet"
+
+	sourceBuiltin = "builtin"
+)
+
+// builtinNetworks are network presets shipped with the cli. Settings not
+// set by preset fall back to global settings, so mainnet uses defaults.
+// Mainnet hosts are fallback hosts of each other.
+var builtinNetworks = map[string]map[string]string{
+	"mainnet":    {"hosts": koios.MainnetHostEU},
+	"mainnet-eu": {"host": koios.MainnetHostEU, "hosts": koios.MainnetHost},
+	"preview":    {"host": koios.PreviewHost},
+	"preprod":    {"host": koios.PreProdHost},
+	"guildnet":   {"host": koios.GuildHost},
+}
+
+// Network is named preset of api client settings. Presets are built-in
+// or defined in config files as networks.<network>.<key>, config files
+// override settings of built-in presets.
+type Network struct {
+	Name     string
+	settings map[string]string
+	sources  map[string]string
+}
+
+// Get returns setting of the preset and its source,
+// path of config file or builtin.
+func (n *Network) Get(key string) (value, source string, ok bool) {
+	value, ok = n.settings[key]
+	return value, n.sources[key], ok
+}
+
+// Network returns network preset by name.
+func (c *Config) Network(name string) (*Network, error) {
+	n := &Network{
+		Name:     name,
+		settings: make(map[string]string),
+		sources:  make(map[string]string),
+	}
+	builtin, found := builtinNetworks[name]
+	for key, value := range builtin {
+		n.settings[key], n.sources[key] = value, sourceBuiltin
+	}
+	prefix := networksKey + "." + name + "."
+	// files are in order of precedence, apply lowest first
+	for i := len(c.files) - 1; i >= 0; i-- {
+		for _, key := range c.files[i].Keys() {
+			if setting, ok := strings.CutPrefix(key, prefix); ok {
+				n.settings[setting], _ = c.files[i].Get(key)
+				n.sources[setting] = c.files[i].Path
+				found = true
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown network %q, available networks are: %s", name, strings.Join(c.Networks(), ", "))
+	}
+	return n, nil
+}
+
+// Networks returns sorted names of built-in and configured networks.
+func (c *Config) Networks() []string {
+	names := make(map[string]bool)
+	for name := range builtinNetworks {
+		names[name] = true
+	}
+	for _, f := range c.files {
+		for _, key := range f.Keys() {
+			if parts := strings.Split(key, "."); len(parts) == 3 && parts[0] == networksKey {
+				names[parts[1]] = true
+			}
+		}
+	}
+	var list []string
+	for name := range names {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func cmdConfigNetworks() *happy.Command {
+	cmd := happy.NewCommand("networks",
+		happy.Option("description", "List network presets available with api --network flag"),
+	)
+	cmd.AddInfo(`
+    Settings not set by preset use global settings or flag defaults.
+
+    Example: koios-cli config set networks.sanchonet.host sancho.koios.rest
+    Example: koios-cli config set networks.sanchonet.hosts sancho-1.example.com,sancho-2.example.com
+    Example: koios-cli api --network sanchonet tip
+  `)
+	cmd.Do(func(sess *happy.Session, args happy.Args) error {
+		cfg, err := Load(sess, selectedProfile(sess, args))
+		if err != nil {
+			return err
+		}
+		tbl := textfmt.Table{
+			Title:      "Networks",
+			WithHeader: true,
+		}
+		tbl.AddRow("Network", "Host", "Fallback Hosts", "Port", "Scheme", "API Version", "Source")
+		for _, name := range cfg.Networks() {
+			n, err := cfg.Network(name)
+			if err != nil {
+				return err
+			}
+			var sources []string
+			row := []string{name}
+			for _, key := range []string{"host", "hosts", "port", "scheme", "api-version"} {
+				value, source, ok := n.Get(key)
+				if !ok {
+					value = "-"
+				} else if !slices.Contains(sources, source) {
+					sources = append(sources, source)
+				}
+				row = append(row, value)
+			}
+			if len(sources) == 0 {
+				sources = append(sources, sourceBuiltin)
+			}
+			tbl.AddRow(append(row, strings.Join(sources, ", "))...)
+		}
+		fmt.Println(tbl.String())
+		return nil
+	})
+	return cmd
+}
@@ -5,8 +5,12 @@
 package main
 
 import (
+	"log/slog"
+	"os"
+
 	"github.com/cardano-community/koios-cli/v2/internal/api"
 	"github.com/cardano-community/koios-cli/v2/internal/auth"
+	"github.com/cardano-community/koios-cli/v2/internal/config"
 	"github.com/cardano-community/koios-cli/v2/koios"
 	"github.com/happy-sdk/happy"
 	"github.com/happy-sdk/happy/sdk/logging"
@@ -31,10 +35,11 @@ func main() {
 		// TimeLocation:   "Local",
 		// StatsEnabled:   false,
 	}).
-		WithLogger(logging.Console(logOpts)).
+		WithLogger(exitLogger{logging.Console(logOpts)}).
 		WithBrand(koios.Brand()).
 		WithCommand(api.Command()).
-		WithCommand(auth.Command())
+		WithCommand(auth.Command()).
+		WithCommand(config.Command())
 
 	app.AddInfo(`
       Example: Usage with Public Tier
@@ -46,9 +51,53 @@ func main() {
       Example: Usage with saved profile (see koios-cli auth -h)
         koios-cli --profile <project-id> api tip
 
+      Example: Usage with default profile, overridden by --profile flag or KOIOS_PROFILE
+        koios-cli auth use <project-id>
+        koios-cli api tip
+
       Example: Usage with saved profile and stats
         koios-cli --profile <project-id> api --stats tip
+
+      Example: Fetch all pages even when daily request quota of the profile would be exceeded
+        koios-cli --profile <project-id> api --force pool_list --all
+
+      Example: Spread requests across tokens of multiple profiles
+        koios-cli api --profiles <project-a>,<project-b> account_list --all
+
+      Example: Set defaults of api flags in config file (see koios-cli config -h)
+        koios-cli config set rate-limit 5
+
+      Example: Fail over to fallback hosts, starting with the fastest one
+        koios-cli api --hosts eu-api.koios.rest --probe-hosts tip
+
+      Example: Output response data as table, csv, ndjson or yaml
+        koios-cli api --output csv pool_list
+
+      Example: Answer only from local response cache, without network requests
+        koios-cli api --offline epoch_params 320
     `)
 
+	os.Args = api.Aliases(os.Args)
 	app.Run()
 }
+
+// shutdownComplete is message happy logs after it has shut down the app,
+// right before it exits.
+const shutdownComplete = "shutdown complete"
+
+// exitLogger exits with exit code of api command error once happy has shut
+// down the app. Happy v0.24 exits within app.Run with code 1 on any error
+// and has no exit hook, so app.Run never returns to map the exit code.
+type exitLogger struct {
+	*logging.DefaultLogger
+}
+
+func (l exitLogger) SystemDebug(msg string, attrs ...slog.Attr) {
+	l.DefaultLogger.SystemDebug(msg, attrs...)
+	if msg != shutdownComplete {
+		return
+	}
+	if code := api.ExitCode(); code != 0 {
+		os.Exit(code)
+	}
+}